@@ -0,0 +1,328 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SortOrder is the order checked by Sorted.
+type SortOrder int
+
+const (
+	Ascending  SortOrder = iota // Each element is greater than or equal to the previous one
+	Descending                  // Each element is less than or equal to the previous one
+)
+
+// MinItems checks if a slice or array has at least min elements, e.g. the objects of an ArrayOf field.
+func MinItems(min int) ValidatorFunc {
+	return func(value interface{}) error {
+		v, err := elements("MinItems", value)
+		if err != nil {
+			return err
+		}
+		if v.Len() < min {
+			return invalid("MinItems", "min_length_items", value, Params{"min": min})
+		}
+		return nil
+	}
+}
+
+// MaxItems checks if a slice or array has at most max elements, e.g. the objects of an ArrayOf field.
+func MaxItems(max int) ValidatorFunc {
+	return func(value interface{}) error {
+		v, err := elements("MaxItems", value)
+		if err != nil {
+			return err
+		}
+		if v.Len() > max {
+			return invalid("MaxItems", "max_length_items", value, Params{"max": max})
+		}
+		return nil
+	}
+}
+
+// UniqueItems checks that no two elements of a slice or array are equal, comparing objects and arrays deeply
+// and numbers by value, so 1 and 1.0 are equal. Every duplicate is reported at its index.
+func UniqueItems() ValidatorFunc {
+	return func(value interface{}) error {
+		v, err := elements("UniqueItems", value)
+		if err != nil {
+			return err
+		}
+		var errs ValidationErrors
+		for i, first := range duplicates(v.Len(), func(i int) (interface{}, bool) { return v.Index(i).Interface(), true }) {
+			if first < 0 {
+				continue
+			}
+			e := invalid("UniqueItems", "unique_items", v.Index(i).Interface(), Params{"index": first})
+			e.Field = fmt.Sprintf("[%d]", i)
+			errs = append(errs, e)
+		}
+		return elementErrors(errs)
+	}
+}
+
+// UniqueBy checks that no two objects of a slice or array hold equal values at key, e.g. UniqueBy("sku").
+// key may be a dotted path such as "product.sku". Objects without the key are ignored, and every duplicate is
+// reported at the path of its key, e.g. "[3].sku".
+func UniqueBy(key string) ValidatorFunc {
+	return func(value interface{}) error {
+		v, err := elements("UniqueBy", value)
+		if err != nil {
+			return err
+		}
+		objects, errs := objectElements("UniqueBy", v)
+		keyed := func(i int) (interface{}, bool) {
+			if objects[i] == nil {
+				return nil, false
+			}
+			return lookupField(FieldContext{Parent: objects[i]}, key)
+		}
+		for i, first := range duplicates(v.Len(), keyed) {
+			if first < 0 {
+				continue
+			}
+			item, _ := keyed(i)
+			e := invalid("UniqueBy", "unique_by", item, Params{"key": key, "index": first})
+			e.Field = joinPath(fmt.Sprintf("[%d]", i), key)
+			errs = append(errs, e)
+		}
+		return elementErrors(errs)
+	}
+}
+
+// Contains checks that at least one element of a slice or array passes fn.
+func Contains(fn ValidatorFunc) ValidatorFunc {
+	return func(value interface{}) error {
+		v, err := elements("Contains", value)
+		if err != nil {
+			return err
+		}
+		if countMatches(v, fn) == 0 {
+			return invalid("Contains", "contains", value, nil)
+		}
+		return nil
+	}
+}
+
+// ContainsBetween checks that between min and max elements of a slice or array pass fn, a negative max meaning no maximum.
+func ContainsBetween(fn ValidatorFunc, min, max int) ValidatorFunc {
+	return func(value interface{}) error {
+		v, err := elements("ContainsBetween", value)
+		if err != nil {
+			return err
+		}
+		count := countMatches(v, fn)
+		if count < min {
+			return invalid("ContainsBetween", "contains_min", value, Params{"min": min, "count": count})
+		}
+		if max >= 0 && count > max {
+			return invalid("ContainsBetween", "contains_max", value, Params{"max": max, "count": count})
+		}
+		return nil
+	}
+}
+
+// Sorted checks that the elements of a slice or array are in order, comparing numbers, dates or strings like GtField.
+// With a key, the objects of the slice are compared by their value at key, which may be a dotted path.
+// The first element out of order is reported at its index.
+func Sorted(order SortOrder, key string) ValidatorFunc {
+	return func(value interface{}) error {
+		v, err := elements("Sorted", value)
+		if err != nil {
+			return err
+		}
+		item := func(i int) interface{} {
+			return v.Index(i).Interface()
+		}
+		if key != "" {
+			objects, errs := objectElements("Sorted", v)
+			if len(errs) > 0 {
+				return errs[0]
+			}
+			item = func(i int) interface{} {
+				item, _ := lookupField(FieldContext{Parent: objects[i]}, key)
+				return item
+			}
+		}
+		for i := 1; i < v.Len(); i++ {
+			c, err := compareValues(item(i-1), item(i))
+			path := joinPath(fmt.Sprintf("[%d]", i), key)
+			if err != nil {
+				e := invalidType("Sorted", "sort_type", item(i), Params{"index": i})
+				e.Field = path
+				return e
+			}
+			if order == Ascending && c > 0 || order == Descending && c < 0 {
+				rule := "sorted_asc"
+				if order == Descending {
+					rule = "sorted_desc"
+				}
+				e := invalid("Sorted", rule, item(i), Params{"index": i})
+				e.Field = path
+				return e
+			}
+		}
+		return nil
+	}
+}
+
+// elements returns value as a slice or array, or the error of rule for other values.
+func elements(rule string, value interface{}) (reflect.Value, error) {
+	if value == nil {
+		return reflect.Value{}, invalidType(rule, "nil", value, nil)
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return reflect.Value{}, invalidType(rule, "slice_or_array", value, nil)
+	}
+	return v, nil
+}
+
+// objectElements returns the elements of v as objects, structs being converted like EachWithOptions does,
+// with an error for every element that is not an object.
+func objectElements(rule string, v reflect.Value) ([]map[string]interface{}, ValidationErrors) {
+	objects := make([]map[string]interface{}, v.Len())
+	var errs ValidationErrors
+	for i := range objects {
+		elem := v.Index(i).Interface()
+		object, ok := elem.(map[string]interface{})
+		if !ok {
+			object = StructToMap(elem)
+		}
+		if object == nil {
+			e := invalidType(rule, "element_object", elem, Params{"index": i, "type": fmt.Sprintf("%T", elem)})
+			e.Field = fmt.Sprintf("[%d]", i)
+			errs = append(errs, e)
+		}
+		objects[i] = object
+	}
+	return objects, errs
+}
+
+// elementErrors returns the failures found in the elements of a slice like EachWithOptions.
+func elementErrors(errs ValidationErrors) error {
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// countMatches returns the number of elements of v passing fn.
+func countMatches(v reflect.Value, fn ValidatorFunc) int {
+	count := 0
+	for i := 0; i < v.Len(); i++ {
+		if fn(v.Index(i).Interface()) == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// duplicates returns, for each of the n items, the index of the first equal item before it or -1.
+// Items for which item reports false are skipped.
+func duplicates(n int, item func(i int) (interface{}, bool)) []int {
+	first := make([]int, n)
+	keys := make(map[string]int, n)
+	var others []int // Items without key, compared with reflect.DeepEqual
+	for i := range first {
+		first[i] = -1
+		value, ok := item(i)
+		if !ok {
+			continue
+		}
+		key, ok := equalityKey(value)
+		if !ok {
+			for _, j := range others {
+				other, _ := item(j)
+				if reflect.DeepEqual(value, other) {
+					first[i] = j
+					break
+				}
+			}
+			if first[i] < 0 {
+				others = append(others, i)
+			}
+			continue
+		}
+		if j, exists := keys[key]; exists {
+			first[i] = j
+		} else {
+			keys[key] = i
+		}
+	}
+	return first
+}
+
+// equalityKey returns a string equal for equal JSON-like values: strings, booleans, null, numbers compared by value,
+// and maps and slices of them. It reports false for other values such as structs.
+func equalityKey(value interface{}) (string, bool) {
+	var b strings.Builder
+	if !writeEqualityKey(&b, value) {
+		return "", false
+	}
+	return b.String(), true
+}
+
+// writeEqualityKey writes the equality key of value to b, see equalityKey.
+func writeEqualityKey(b *strings.Builder, value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		b.WriteString("null")
+		return true
+	case string:
+		b.WriteString(strconv.Quote(v))
+		return true
+	case bool:
+		b.WriteString(strconv.FormatBool(v))
+		return true
+	}
+	if r, ok := toDecimal(value); ok {
+		b.WriteString(r.RatString())
+		return true
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		b.WriteByte('[')
+		for i := 0; i < rv.Len(); i++ {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			if !writeEqualityKey(b, rv.Index(i).Interface()) {
+				return false
+			}
+		}
+		b.WriteByte(']')
+		return true
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return false
+		}
+		keys := make([]string, 0, rv.Len())
+		for iter := rv.MapRange(); iter.Next(); {
+			keys = append(keys, iter.Key().String())
+		}
+		sort.Strings(keys)
+		b.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(strconv.Quote(key))
+			b.WriteByte(':')
+			if !writeEqualityKey(b, rv.MapIndex(reflect.ValueOf(key).Convert(rv.Type().Key())).Interface()) {
+				return false
+			}
+		}
+		b.WriteByte('}')
+		return true
+	}
+	return false
+}
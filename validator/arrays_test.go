@@ -0,0 +1,103 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArrayValidators(t *testing.T) {
+	type line struct {
+		SKU string `json:"sku"`
+	}
+	item := func(sku string, qty int) map[string]interface{} {
+		return map[string]interface{}{"sku": sku, "qty": qty}
+	}
+	tests := []struct {
+		name  string
+		fn    ValidatorFunc
+		input interface{}
+		error error
+	}{
+		{"min items", MinItems(1), []interface{}{item("a", 1)}, nil},
+		{"too few items", MinItems(2), []interface{}{item("a", 1)}, errors.New("value must have at least 2 elements")},
+		{"too many items", MaxItems(1), []int{1, 2}, errors.New("value must have at most 1 elements")},
+		{"items of a string", MaxItems(1), "ab", errors.New("value must be a slice or array")},
+		{"unique", UniqueItems(), []interface{}{"a", "b", 1, true, nil}, nil},
+		{"duplicate string", UniqueItems(), []string{"a", "b", "a"}, errors.New("value duplicates the element at index 0")},
+		{"duplicate number", UniqueItems(), []interface{}{1, json.Number("2"), 1.0}, errors.New("value duplicates the element at index 0")},
+		{"string and number", UniqueItems(), []interface{}{"1", 1}, nil},
+		{"duplicate object", UniqueItems(), []interface{}{item("a", 1), item("a", 2), item("a", 1)}, errors.New("value duplicates the element at index 0")},
+		{"duplicate struct", UniqueItems(), []line{{"a"}, {"a"}}, errors.New("value duplicates the element at index 0")},
+		{"unique by", UniqueBy("sku"), []interface{}{item("a", 1), item("b", 1), map[string]interface{}{}}, nil},
+		{"duplicate key", UniqueBy("sku"), []interface{}{item("a", 1), item("b", 1), item("a", 2)}, errors.New("sku must be unique, the element at index 0 has the same value")},
+		{"duplicate key of structs", UniqueBy("sku"), []line{{"a"}, {"a"}}, errors.New("sku must be unique, the element at index 0 has the same value")},
+		{"unique by of scalars", UniqueBy("sku"), []interface{}{1}, errors.New("element at index 0 must be an object, got int")},
+		{"contains", Contains(IsIn("admin")), []interface{}{"user", "admin"}, nil},
+		{"does not contain", Contains(IsIn("admin")), []interface{}{"user"}, errors.New("value must contain a matching element")},
+		{"contains between", ContainsBetween(IsInt, 1, 2), []interface{}{1, "a", 2}, nil},
+		{"contains too few", ContainsBetween(IsInt, 2, -1), []interface{}{1, "a"}, errors.New("value must contain at least 2 matching elements, got 1")},
+		{"contains too many", ContainsBetween(IsInt, 0, 1), []interface{}{1, 2}, errors.New("value must contain at most 1 matching elements, got 2")},
+		{"sorted", Sorted(Ascending, ""), []interface{}{1, 2.5, 2.5, 3}, nil},
+		{"not sorted", Sorted(Ascending, ""), []string{"a", "c", "b"}, errors.New("value must be sorted in ascending order")},
+		{"sorted descending by key", Sorted(Descending, "date"), []interface{}{map[string]interface{}{"date": "2024-02-01"}, map[string]interface{}{"date": "2024-01-01"}}, nil},
+		{"not sorted descending", Sorted(Descending, "qty"), []interface{}{item("a", 1), item("b", 2)}, errors.New("value must be sorted in descending order")},
+		{"not comparable", Sorted(Ascending, ""), []interface{}{1, "a"}, errors.New("element at index 1 cannot be compared with the previous one")},
+		{"nil", UniqueItems(), nil, errors.New("value is nil")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.fn(test.input)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
+		})
+	}
+
+	t.Run("error paths", func(t *testing.T) {
+		options := Object(
+			Field("tags").UniqueItems(),
+			Field("items").MinItems(1).MaxItems(10).UniqueBy("product.sku").Sorted(Ascending, "position"),
+		)
+		body := map[string]interface{}{
+			"tags": []interface{}{"a", "b", "a", "a"},
+			"items": []interface{}{
+				map[string]interface{}{"position": 1, "product": map[string]interface{}{"sku": "X"}},
+				map[string]interface{}{"position": 2, "product": map[string]interface{}{"sku": "X"}},
+			},
+		}
+		err := ValidateAll(body, options)
+		var errs ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		fields := make([]string, len(errs))
+		for i, e := range errs {
+			fields[i] = e.(*ValidationError).Field
+		}
+		require.Equal(t, []string{"tags[2]", "tags[3]", "items[1].product.sku"}, fields)
+		require.Equal(t, Params{"key": "product.sku", "index": 0}, errs[2].(*ValidationError).Params)
+
+		body["items"].([]interface{})[0].(map[string]interface{})["position"] = 3
+		body["items"].([]interface{})[0].(map[string]interface{})["product"] = map[string]interface{}{"sku": "Y"}
+		err = Validate(map[string]interface{}{"items": body["items"]}, options[1:])
+		var ve *ValidationError
+		require.ErrorAs(t, err, &ve)
+		require.Equal(t, "items[1].position", ve.Field)
+	})
+
+	t.Run("large numbers are compared quickly", func(t *testing.T) {
+		tiny := json.Number("0." + strings.Repeat("0", 100000) + "1")
+		start := time.Now()
+		require.NoError(t, UniqueItems()([]interface{}{tiny, json.Number("1e-1000000"), json.Number("1e1000"), json.Number("1e-1000"), 1e300}))
+		require.EqualError(t, UniqueItems()([]interface{}{tiny, json.Number("1e1000"), tiny}), "value duplicates the element at index 0")
+		require.Less(t, time.Since(start), time.Second)
+		require.EqualError(t, UniqueItems()([]interface{}{1.0, json.Number("1")}), "value duplicates the element at index 0")
+		require.EqualError(t, UniqueItems()([]interface{}{0.5, json.Number("5e-1")}), "value duplicates the element at index 0")
+	})
+}
@@ -0,0 +1,225 @@
+package validator
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Bind validates body against options like ValidateContext, then decodes the transformed body into a T, see Decode.
+func Bind[T any](body map[string]interface{}, options []ValidationOption, opts ...RunOption) (T, error) {
+	return BindContext[T](context.Background(), body, options, opts...)
+}
+
+// BindContext is like Bind but validates with the given context.
+func BindContext[T any](ctx context.Context, body map[string]interface{}, options []ValidationOption, opts ...RunOption) (T, error) {
+	if err := ValidateContext(ctx, body, options, opts...); err != nil {
+		var zero T
+		return zero, err
+	}
+	return Decode[T](body)
+}
+
+// Decode decodes a body into a T, usually a struct, mapping keys onto fields by their json tag names like encoding/json,
+// including the fields promoted from embedded structs.
+// Numbers, including json.Number and math/big values, decode into integer fields when they are whole and in range,
+// RFC3339 and YYYY-MM-DD strings into time.Time, other strings and json.Number values into encoding.TextUnmarshaler fields
+// such as *big.Int, and nested objects and arrays into structs, maps and slices. Fields with the string option of a json tag,
+// e.g. `json:"id,string"`, take their value from the JSON literal inside a string, as encoding/json and StructToMap write it.
+// An invalid validate tag on a struct type is reported as an *InternalError.
+// A value that can't be decoded is reported as a *ValidationError with the path of the field.
+func Decode[T any](body map[string]interface{}) (T, error) {
+	var result T
+	if err := decode(body, reflect.ValueOf(&result).Elem(), ""); err != nil {
+		var zero T
+		return zero, err
+	}
+	return result, nil
+}
+
+// decode sets rv to value, converting it to the type of rv.
+func decode(value interface{}, rv reflect.Value, path string) error {
+	if value == nil {
+		rv.SetZero()
+		return nil
+	}
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return decode(value, rv.Elem(), path)
+	}
+
+	vv := reflect.ValueOf(value)
+	// Values that already have the right type, e.g. set by a transformer
+	if vv.Type().AssignableTo(rv.Type()) {
+		rv.Set(vv)
+		return nil
+	}
+	if rv.Type() == timeType {
+		t, ok := toTime(value)
+		if !ok {
+			return decodeError(value, rv, path)
+		}
+		rv.Set(reflect.ValueOf(t))
+		return nil
+	}
+	if s, ok := decodeText(value); ok {
+		if u, ok := rv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			if err := u.UnmarshalText([]byte(s)); err != nil {
+				return decodeError(value, rv, path)
+			}
+			return nil
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		if _, ok := value.(json.Number); ok || vv.Kind() != reflect.String {
+			return decodeError(value, rv, path)
+		}
+		rv.SetString(vv.String())
+	case reflect.Bool:
+		if vv.Kind() != reflect.Bool {
+			return decodeError(value, rv, path)
+		}
+		rv.SetBool(vv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := toNumber(value)
+		i, exact := n.int64()
+		if !ok || !exact || rv.OverflowInt(i) {
+			return decodeError(value, rv, path)
+		}
+		rv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, ok := toNumber(value)
+		u, exact := n.uint64()
+		if !ok || !exact || rv.OverflowUint(u) {
+			return decodeError(value, rv, path)
+		}
+		rv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		n, ok := toNumber(value)
+		if !ok || rv.OverflowFloat(n.float64()) {
+			return decodeError(value, rv, path)
+		}
+		rv.SetFloat(n.float64())
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return decodeError(value, rv, path)
+		}
+		return decodeStruct(object, rv, path)
+	case reflect.Slice, reflect.Array:
+		if vv.Kind() != reflect.Slice && vv.Kind() != reflect.Array {
+			return decodeError(value, rv, path)
+		}
+		if rv.Kind() == reflect.Slice {
+			rv.Set(reflect.MakeSlice(rv.Type(), vv.Len(), vv.Len()))
+		} else if vv.Len() > rv.Len() {
+			return decodeError(value, rv, path)
+		}
+		for i := 0; i < vv.Len(); i++ {
+			if err := decode(vv.Index(i).Interface(), rv.Index(i), joinPath(path, fmt.Sprintf("[%d]", i))); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if vv.Kind() != reflect.Map || vv.Type().Key().Kind() != reflect.String || rv.Type().Key().Kind() != reflect.String {
+			return decodeError(value, rv, path)
+		}
+		rv.Set(reflect.MakeMapWithSize(rv.Type(), vv.Len()))
+		iter := vv.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			if err := decode(iter.Value().Interface(), elem, joinPath(path, key)); err != nil {
+				return err
+			}
+			rv.SetMapIndex(reflect.ValueOf(key).Convert(rv.Type().Key()), elem)
+		}
+	default:
+		return decodeError(value, rv, path)
+	}
+	return nil
+}
+
+// decodeStruct sets the fields of rv to the values of object, resolving keys onto fields like StructToMap,
+// so fields promoted from embedded structs, including unexported ones, are read from object too.
+func decodeStruct(object map[string]interface{}, rv reflect.Value, path string) error {
+	if _, err := structFields(rv.Type()); err != nil {
+		return Internal(err)
+	}
+	for _, field := range jsonFields(rv.Type()) {
+		value, ok := object[field.name]
+		if !ok {
+			continue
+		}
+		fv, err := settableField(rv, field.index)
+		if err != nil {
+			return Internal(err)
+		}
+		if s, ok := value.(string); ok && field.quoted {
+			if err := decodeQuoted(s, fv, joinPath(path, field.name)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := decode(value, fv, joinPath(path, field.name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// settableField returns the field at index, allocating the nil embedded pointers on its way.
+// Like encoding/json, it fails on a nil pointer to an unexported struct, which can't be allocated.
+func settableField(rv reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				if !rv.CanSet() {
+					return reflect.Value{}, fmt.Errorf("validator: cannot set embedded pointer to unexported struct %s", rv.Type().Elem())
+				}
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, nil
+}
+
+// decodeText returns the text of strings and json.Number values for encoding.TextUnmarshaler fields.
+func decodeText(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return string(v), true
+	}
+	return "", false
+}
+
+// decodeQuoted sets rv to the value encoded in s for a field with the string option of encoding/json,
+// e.g. 42 for "42" or "a" for `"a"`, like StructToMap writes it.
+func decodeQuoted(s string, rv reflect.Value, path string) error {
+	target := reflect.New(rv.Type())
+	if err := json.Unmarshal([]byte(s), target.Interface()); err != nil {
+		return decodeError(s, rv, path)
+	}
+	rv.Set(target.Elem())
+	return nil
+}
+
+// decodeError reports a value that can't be decoded into rv.
+func decodeError(value interface{}, rv reflect.Value, path string) *ValidationError {
+	e := invalidType("Decode", "decode_type", value, Params{"type": rv.Type().String()})
+	e.Field = path
+	return e
+}
@@ -0,0 +1,198 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type bindAddress struct {
+	City string `json:"city"`
+	Zip  *int   `json:"zip"`
+}
+
+type Timestamps struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type bindOrder struct {
+	Timestamps
+	ID       uint64            `json:"id"`
+	Quantity int8              `json:"quantity"`
+	Price    float32           `json:"price"`
+	Email    string            `json:"email"`
+	Paid     bool              `json:"paid"`
+	Due      time.Time         `json:"due"`
+	IP       netip.Addr        `json:"ip"`
+	Address  bindAddress       `json:"address"`
+	Billing  *bindAddress      `json:"billing"`
+	Tags     []string          `json:"tags"`
+	Lines    []bindAddress     `json:"lines"`
+	Meta     map[string]int    `json:"meta"`
+	Extra    interface{}       `json:"extra"`
+	Ignored  string            `json:"-"`
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+func TestBind(t *testing.T) {
+	body := func() map[string]interface{} {
+		return map[string]interface{}{
+			"created_at": "2024-01-02T03:04:05Z",
+			"id":         float64(42),
+			"quantity":   float64(3),
+			"price":      9.5,
+			"email":      " Alice@Example.com ",
+			"paid":       true,
+			"due":        "2024-02-01",
+			"ip":         "127.0.0.1",
+			"address":    map[string]interface{}{"city": "Paris", "zip": float64(75001)},
+			"billing":    map[string]interface{}{"city": "Lyon"},
+			"tags":       []interface{}{"a", "b"},
+			"lines":      []interface{}{map[string]interface{}{"city": "Nice"}},
+			"meta":       map[string]interface{}{"n": float64(1)},
+			"extra":      map[string]interface{}{"any": "thing"},
+			"Ignored":    "x",
+			"unknown":    "y",
+		}
+	}
+	options := Object(Field("email").Trim().ToLower().Email())
+
+	t.Run("validated body is decoded", func(t *testing.T) {
+		order, err := Bind[bindOrder](body(), options)
+		require.NoError(t, err)
+
+		zip := 75001
+		require.Equal(t, bindOrder{
+			Timestamps: Timestamps{CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+			ID:         42,
+			Quantity:   3,
+			Price:      9.5,
+			Email:      "alice@example.com",
+			Paid:       true,
+			Due:        time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			IP:         netip.MustParseAddr("127.0.0.1"),
+			Address:    bindAddress{City: "Paris", Zip: &zip},
+			Billing:    &bindAddress{City: "Lyon"},
+			Tags:       []string{"a", "b"},
+			Lines:      []bindAddress{{City: "Nice"}},
+			Meta:       map[string]int{"n": 1},
+			Extra:      map[string]interface{}{"any": "thing"},
+		}, order)
+	})
+
+	t.Run("validation errors are returned", func(t *testing.T) {
+		b := body()
+		b["email"] = "invalid"
+		_, err := Bind[bindOrder](b, options)
+		require.EqualError(t, err, "value is not a valid email address")
+	})
+
+	t.Run("decoding errors have the field path", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			key   string
+			value interface{}
+			path  string
+			error string
+		}{
+			{"fractional integer", "quantity", 1.5, "quantity", "value cannot be decoded into int8"},
+			{"integer overflow", "quantity", float64(300), "quantity", "value cannot be decoded into int8"},
+			{"negative unsigned", "id", float64(-1), "id", "value cannot be decoded into uint64"},
+			{"invalid time", "due", "tomorrow", "due", "value cannot be decoded into time.Time"},
+			{"invalid text", "ip", "localhost", "ip", "value cannot be decoded into netip.Addr"},
+			{"wrong nested type", "lines", []interface{}{map[string]interface{}{"zip": "x"}}, "lines[0].zip", "value cannot be decoded into int"},
+			{"wrong map value", "meta", map[string]interface{}{"n": "x"}, "meta.n", "value cannot be decoded into int"},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				b := body()
+				b[test.key] = test.value
+				_, err := Decode[bindOrder](b)
+				require.EqualError(t, err, test.error)
+				var e *ValidationError
+				require.ErrorAs(t, err, &e)
+				require.Equal(t, test.path, e.Field)
+				require.Equal(t, CodeInvalidType, e.Code)
+			})
+		}
+	})
+
+	t.Run("string option", func(t *testing.T) {
+		type account struct {
+			ID      int64   `json:"id,string"`
+			Balance float64 `json:"balance,string"`
+			Active  bool    `json:",string"`
+			Name    string  `json:"name,string"`
+			Limit   *uint   `json:"limit,string,omitempty"`
+		}
+		limit := uint(500)
+		want := account{ID: 9007199254740993, Balance: 12.5, Active: true, Name: "Ann", Limit: &limit}
+
+		got, err := Decode[account](StructToMap(want))
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(`{"id":"42","balance":"1e2","Active":"false","name":"\"Bob\""}`), &body))
+		got, err = Decode[account](body)
+		require.NoError(t, err)
+		require.Equal(t, account{ID: 42, Balance: 100, Name: "Bob"}, got)
+
+		body["id"] = "4.2"
+		_, err = Decode[account](body)
+		var e *ValidationError
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, "id", e.Field)
+		require.EqualError(t, err, "value cannot be decoded into int64")
+	})
+
+	t.Run("embedded structs", func(t *testing.T) {
+		type inner struct {
+			A int    `json:"a"`
+			B string `json:"b"`
+		}
+		type base struct {
+			ID int `json:"id"`
+		}
+		type outer struct {
+			inner
+			*Timestamps
+			*base
+			B string `json:"b"`
+		}
+		var want outer
+		data := []byte(`{"a": 3, "b": "outer", "created_at": "2024-01-02T03:04:05Z"}`)
+		require.NoError(t, json.Unmarshal(data, &want))
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &body))
+
+		got, err := Decode[outer](body)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+		require.Equal(t, 3, got.A)
+		require.Empty(t, got.inner.B)
+		require.Equal(t, 2024, got.CreatedAt.Year())
+
+		body["id"] = float64(1)
+		_, err = Decode[outer](body)
+		var internal *InternalError
+		require.ErrorAs(t, err, &internal)
+		require.EqualError(t, internal.Err, "validator: cannot set embedded pointer to unexported struct validator.base")
+	})
+
+	t.Run("invalid validate tags are internal errors", func(t *testing.T) {
+		type tagged struct {
+			Name string `json:"name" validate:"required,startswith=a"`
+		}
+		_, err := Decode[tagged](map[string]interface{}{"name": "ann"})
+		var internal *InternalError
+		require.ErrorAs(t, err, &internal)
+		require.EqualError(t, internal.Err, `invalid validate tag on field validator.tagged.Name: unknown rule "startswith"`)
+
+		_, err = Bind[tagged](map[string]interface{}{"name": "ann"}, Object(Field("name").String()))
+		require.ErrorAs(t, err, &internal)
+	})
+}
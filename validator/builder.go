@@ -0,0 +1,447 @@
+package validator
+
+import "slices"
+
+// FieldSpec is anything that describes the validation of a single field, either a FieldBuilder or a raw ValidationOption.
+type FieldSpec interface {
+	Option() ValidationOption
+}
+
+// Option returns the option itself so raw options can be mixed with builders.
+func (o ValidationOption) Option() ValidationOption {
+	return o
+}
+
+// Object compiles field specs into validation options, e.g.
+//
+//	validator.Object(
+//		validator.Field("email").Trim().ToLower().Email("Invalid email"),
+//		validator.Field("age").Optional().Int().Min(18),
+//	)
+func Object(fields ...FieldSpec) []ValidationOption {
+	options := make([]ValidationOption, len(fields))
+	for i, field := range fields {
+		options[i] = field.Option()
+	}
+	return options
+}
+
+// FieldBuilder builds the ValidationOption of a field through chained calls.
+// Fields are required unless Optional is called.
+type FieldBuilder struct {
+	option ValidationOption
+}
+
+// Field starts building the validation of the field with the given key.
+func Field(key string) *FieldBuilder {
+	return &FieldBuilder{option: ValidationOption{Key: key}}
+}
+
+// Option returns the built ValidationOption.
+func (b *FieldBuilder) Option() ValidationOption {
+	option := b.option
+	option.Validators = slices.Clone(option.Validators)
+	option.Transformers = slices.Clone(option.Transformers)
+	option.FallibleTransformers = slices.Clone(option.FallibleTransformers)
+	option.Nested = slices.Clone(option.Nested)
+	option.Branches = slices.Clone(option.Branches)
+	return option
+}
+
+// message returns the optional custom message passed to a builder method.
+func message(msg []string) string {
+	if len(msg) == 0 {
+		return ""
+	}
+	return msg[0]
+}
+
+// Required marks the field as required, which is the default.
+func (b *FieldBuilder) Required() *FieldBuilder {
+	b.option.IsOptional = false
+	return b
+}
+
+// Optional marks the field as optional.
+func (b *FieldBuilder) Optional() *FieldBuilder {
+	b.option.IsOptional = true
+	return b
+}
+
+// Nullable makes the field accept null.
+func (b *FieldBuilder) Nullable() *FieldBuilder {
+	b.option.Nullable = true
+	return b
+}
+
+// Default sets the value written into the body when the field is absent, see ValidationOption.Default.
+func (b *FieldBuilder) Default(value interface{}) *FieldBuilder {
+	b.option.Default = value
+	return b
+}
+
+// Transform adds transformers to the field.
+func (b *FieldBuilder) Transform(transformers ...Transformer) *FieldBuilder {
+	if len(b.option.FallibleTransformers) > 0 {
+		// Keep the order of the calls once a fallible transformer was added
+		for _, transformer := range transformers {
+			b.option.FallibleTransformers = append(b.option.FallibleTransformers, Fallible(transformer))
+		}
+		return b
+	}
+	b.option.Transformers = append(b.option.Transformers, transformers...)
+	return b
+}
+
+// TryTransform adds transformers that can fail to the field.
+func (b *FieldBuilder) TryTransform(transformers ...FallibleTransformer) *FieldBuilder {
+	b.option.FallibleTransformers = append(b.option.FallibleTransformers, transformers...)
+	return b
+}
+
+// Validate adds a validator to the field.
+func (b *FieldBuilder) Validate(fn ValidatorFunc, msg ...string) *FieldBuilder {
+	b.option.Validators = append(b.option.Validators, CreateValidator(fn, message(msg)))
+	return b
+}
+
+// ValidateContext adds a context validator to the field.
+func (b *FieldBuilder) ValidateContext(fn ContextValidatorFunc, msg ...string) *FieldBuilder {
+	b.option.Validators = append(b.option.Validators, CreateContextValidator(fn, message(msg)))
+	return b
+}
+
+// Expensive marks the most recently added validator as expensive, see Concurrency.
+func (b *FieldBuilder) Expensive() *FieldBuilder {
+	if n := len(b.option.Validators); n > 0 {
+		b.option.Validators[n-1].Expensive = true
+	}
+	return b
+}
+
+// CrossField adds a cross-field validator to the field.
+func (b *FieldBuilder) CrossField(fn CrossFieldFunc, msg ...string) *FieldBuilder {
+	b.option.Validators = append(b.option.Validators, CreateCrossFieldValidator(fn, message(msg)))
+	return b
+}
+
+// Object validates the field as a nested object.
+func (b *FieldBuilder) Object(fields ...FieldSpec) *FieldBuilder {
+	b.option.Nested = Object(fields...)
+	return b
+}
+
+// Ref validates the field as a nested object against the definition name of the registry of the run, see Registry.
+func (b *FieldBuilder) Ref(name string) *FieldBuilder {
+	b.option.Ref = name
+	return b
+}
+
+// UnknownKeys sets the policy for keys of the field's nested objects and ArrayOf elements that no option declares.
+func (b *FieldBuilder) UnknownKeys(policy UnknownKeys) *FieldBuilder {
+	b.option.UnknownKeys = policy
+	return b
+}
+
+// Strict rejects unknown keys in the field's nested objects and ArrayOf elements.
+func (b *FieldBuilder) Strict() *FieldBuilder { return b.UnknownKeys(RejectUnknownKeys) }
+
+// StripUnknown deletes unknown keys from the field's nested objects and ArrayOf elements.
+func (b *FieldBuilder) StripUnknown() *FieldBuilder { return b.UnknownKeys(StripUnknownKeys) }
+
+// ArrayOf validates the field as an array of objects. Compile checks the options of the elements.
+func (b *FieldBuilder) ArrayOf(fields ...FieldSpec) *FieldBuilder {
+	options := Object(fields...)
+	b.ValidateContext(EachWithOptionsContext(options))
+	b.option.Validators[len(b.option.Validators)-1].elements = options
+	return b
+}
+
+// ArrayOfRef validates the field as an array of objects against the definition name of the registry of the run, see EachRef.
+// Compile checks the reference like a Ref option.
+func (b *FieldBuilder) ArrayOfRef(name string) *FieldBuilder {
+	b.ValidateContext(EachRef(name))
+	b.option.Validators[len(b.option.Validators)-1].elementsRef = name
+	return b
+}
+
+// MinItems checks the minimum number of elements of the field's array.
+func (b *FieldBuilder) MinItems(min int, msg ...string) *FieldBuilder {
+	return b.Validate(MinItems(min), msg...)
+}
+
+// MaxItems checks the maximum number of elements of the field's array.
+func (b *FieldBuilder) MaxItems(max int, msg ...string) *FieldBuilder {
+	return b.Validate(MaxItems(max), msg...)
+}
+
+// UniqueItems checks that the elements of the field's array are distinct.
+func (b *FieldBuilder) UniqueItems(msg ...string) *FieldBuilder {
+	return b.Validate(UniqueItems(), msg...)
+}
+
+// UniqueBy checks that the objects of the field's array hold distinct values at key.
+func (b *FieldBuilder) UniqueBy(key string, msg ...string) *FieldBuilder {
+	return b.Validate(UniqueBy(key), msg...)
+}
+
+// Contains checks that an element of the field's array passes fn.
+func (b *FieldBuilder) Contains(fn ValidatorFunc, msg ...string) *FieldBuilder {
+	return b.Validate(Contains(fn), msg...)
+}
+
+// ContainsBetween checks the number of elements of the field's array passing fn.
+func (b *FieldBuilder) ContainsBetween(fn ValidatorFunc, min, max int, msg ...string) *FieldBuilder {
+	return b.Validate(ContainsBetween(fn, min, max), msg...)
+}
+
+// Sorted checks that the field's array is sorted, by the value at key of its objects when key is not empty.
+func (b *FieldBuilder) Sorted(order SortOrder, key string, msg ...string) *FieldBuilder {
+	return b.Validate(Sorted(order, key), msg...)
+}
+
+// MapOf validates the keys and values of the field's object, see MapOf.
+func (b *FieldBuilder) MapOf(keys []ValidatorFunc, value FieldSpec) *FieldBuilder {
+	return b.ValidateContext(MapOfContext(keys, value))
+}
+
+// MinProperties checks the minimum number of keys of the field's object.
+func (b *FieldBuilder) MinProperties(min int, msg ...string) *FieldBuilder {
+	return b.Validate(MinProperties(min), msg...)
+}
+
+// MaxProperties checks the maximum number of keys of the field's object.
+func (b *FieldBuilder) MaxProperties(max int, msg ...string) *FieldBuilder {
+	return b.Validate(MaxProperties(max), msg...)
+}
+
+// Each validates every element of the field's array.
+func (b *FieldBuilder) Each(fn ValidatorFunc, msg ...string) *FieldBuilder {
+	return b.Validate(Each(fn), msg...)
+}
+
+// Trim trims leading and trailing whitespace.
+func (b *FieldBuilder) Trim() *FieldBuilder { return b.Transform(Trim) }
+
+// ToLower converts the value to lowercase.
+func (b *FieldBuilder) ToLower() *FieldBuilder { return b.Transform(ToLower) }
+
+// ToUpper converts the value to uppercase.
+func (b *FieldBuilder) ToUpper() *FieldBuilder { return b.Transform(ToUpper) }
+
+// ToTitleCase converts the value to title case.
+func (b *FieldBuilder) ToTitleCase() *FieldBuilder { return b.Transform(ToTitleCase) }
+
+// RemoveSpecialChars removes special characters.
+func (b *FieldBuilder) RemoveSpecialChars() *FieldBuilder { return b.Transform(RemoveSpecialChars) }
+
+// ToInt converts the value to an integer.
+func (b *FieldBuilder) ToInt() *FieldBuilder { return b.Transform(ToInt) }
+
+// ToFloat converts the value to a float.
+func (b *FieldBuilder) ToFloat() *FieldBuilder { return b.Transform(ToFloat) }
+
+// ToIntStrict converts the value to an integer, failing when it can't be converted.
+func (b *FieldBuilder) ToIntStrict() *FieldBuilder { return b.TryTransform(ToIntStrict) }
+
+// ToFloatStrict converts the value to a float, failing when it can't be converted.
+func (b *FieldBuilder) ToFloatStrict() *FieldBuilder { return b.TryTransform(ToFloatStrict) }
+
+// ToBoolStrict converts the value to a boolean, failing when it can't be converted.
+func (b *FieldBuilder) ToBoolStrict() *FieldBuilder { return b.TryTransform(ToBoolStrict) }
+
+// ToDecimal converts a decimal string or number to a canonical decimal string, failing on other values.
+func (b *FieldBuilder) ToDecimal() *FieldBuilder { return b.TryTransform(ToDecimal) }
+
+// ToFixedDecimal converts a decimal string or number to a decimal string with exactly places decimal places.
+func (b *FieldBuilder) ToFixedDecimal(places int) *FieldBuilder {
+	return b.TryTransform(ToFixedDecimal(places))
+}
+
+// Truncate truncates the value to a maximum length.
+func (b *FieldBuilder) Truncate(maxLength int) *FieldBuilder { return b.Transform(Truncate(maxLength)) }
+
+// Replace replaces occurrences of a substring.
+func (b *FieldBuilder) Replace(old, new string) *FieldBuilder { return b.Transform(Replace(old, new)) }
+
+// NotEmpty checks that the value is not empty.
+func (b *FieldBuilder) NotEmpty(msg ...string) *FieldBuilder { return b.Validate(IsNotEmpty, msg...) }
+
+// Alphanumeric checks that the value contains only alphanumeric characters.
+func (b *FieldBuilder) Alphanumeric(msg ...string) *FieldBuilder {
+	return b.Validate(IsAlphanumeric, msg...)
+}
+
+// Alpha checks that the value contains only alphabetic characters.
+func (b *FieldBuilder) Alpha(msg ...string) *FieldBuilder { return b.Validate(IsAlpha, msg...) }
+
+// Arabic checks that the value contains only Arabic characters.
+func (b *FieldBuilder) Arabic(msg ...string) *FieldBuilder { return b.Validate(IsArabic, msg...) }
+
+// AlphaArabic checks that the value contains only Arabic and Latin alphabetic characters.
+func (b *FieldBuilder) AlphaArabic(msg ...string) *FieldBuilder {
+	return b.Validate(IsAlphaArabic, msg...)
+}
+
+// Email checks that the value is a valid email address.
+func (b *FieldBuilder) Email(msg ...string) *FieldBuilder { return b.Validate(IsEmail, msg...) }
+
+// URL checks that the value is a valid URL.
+func (b *FieldBuilder) URL(msg ...string) *FieldBuilder { return b.Validate(IsURL, msg...) }
+
+// UUID checks that the value is a valid UUID.
+func (b *FieldBuilder) UUID(msg ...string) *FieldBuilder { return b.Validate(IsUUID, msg...) }
+
+// Date checks that the value is a valid YYYY-MM-DD date.
+func (b *FieldBuilder) Date(msg ...string) *FieldBuilder { return b.Validate(IsDate, msg...) }
+
+// Time checks that the value is a valid HH:MM:SS time.
+func (b *FieldBuilder) Time(msg ...string) *FieldBuilder { return b.Validate(IsTime, msg...) }
+
+// IP checks that the value is a valid IP address.
+func (b *FieldBuilder) IP(msg ...string) *FieldBuilder { return b.Validate(IsIP, msg...) }
+
+// JSON checks that the value is valid JSON.
+func (b *FieldBuilder) JSON(msg ...string) *FieldBuilder { return b.Validate(IsJSON, msg...) }
+
+// HexColor checks that the value is a valid hexadecimal color code.
+func (b *FieldBuilder) HexColor(msg ...string) *FieldBuilder { return b.Validate(IsHexColor, msg...) }
+
+// CreditCard checks that the value is a valid credit card number.
+func (b *FieldBuilder) CreditCard(msg ...string) *FieldBuilder {
+	return b.Validate(IsCreditCard, msg...)
+}
+
+// Base64 checks that the value is valid Base64.
+func (b *FieldBuilder) Base64(msg ...string) *FieldBuilder { return b.Validate(IsBase64, msg...) }
+
+// Base64Image checks that the value is a valid Base64-encoded image.
+func (b *FieldBuilder) Base64Image(msg ...string) *FieldBuilder {
+	return b.Validate(IsBase64Image, msg...)
+}
+
+// String checks that the value is a string.
+func (b *FieldBuilder) String(msg ...string) *FieldBuilder { return b.Validate(IsString, msg...) }
+
+// Number checks that the value is a number.
+func (b *FieldBuilder) Number(msg ...string) *FieldBuilder { return b.Validate(IsNumber, msg...) }
+
+// Int checks that the value is an integer.
+func (b *FieldBuilder) Int(msg ...string) *FieldBuilder { return b.Validate(IsInt, msg...) }
+
+// Float checks that the value is a float.
+func (b *FieldBuilder) Float(msg ...string) *FieldBuilder { return b.Validate(IsFloat, msg...) }
+
+// Bool checks that the value is a boolean.
+func (b *FieldBuilder) Bool(msg ...string) *FieldBuilder { return b.Validate(IsBool, msg...) }
+
+// Slice checks that the value is a slice.
+func (b *FieldBuilder) Slice(msg ...string) *FieldBuilder { return b.Validate(IsSlice, msg...) }
+
+// Map checks that the value is a map.
+func (b *FieldBuilder) Map(msg ...string) *FieldBuilder { return b.Validate(IsMap, msg...) }
+
+// Regex checks that the value matches a regular expression.
+func (b *FieldBuilder) Regex(pattern string, msg ...string) *FieldBuilder {
+	return b.Validate(Regex(pattern), msg...)
+}
+
+// OneOf checks that the value is one of the allowed values.
+func (b *FieldBuilder) OneOf(allowedValues []interface{}, msg ...string) *FieldBuilder {
+	return b.Validate(IsIn(allowedValues...), msg...)
+}
+
+// NotOneOf checks that the value is none of the disallowed values.
+func (b *FieldBuilder) NotOneOf(disallowedValues []interface{}, msg ...string) *FieldBuilder {
+	return b.Validate(IsNotIn(disallowedValues...), msg...)
+}
+
+// MinLength checks the minimum length of a string, slice or array.
+func (b *FieldBuilder) MinLength(min int, msg ...string) *FieldBuilder {
+	return b.Validate(MinLength(min), msg...)
+}
+
+// MaxLength checks the maximum length of a string, slice or array.
+func (b *FieldBuilder) MaxLength(max int, msg ...string) *FieldBuilder {
+	return b.Validate(MaxLength(max), msg...)
+}
+
+// Length checks that the length of a string is within a range.
+func (b *FieldBuilder) Length(min, max int, msg ...string) *FieldBuilder {
+	return b.Validate(Length(min, max), msg...)
+}
+
+// Min checks the minimum value of a number.
+func (b *FieldBuilder) Min(min float64, msg ...string) *FieldBuilder {
+	return b.Validate(Min(min), msg...)
+}
+
+// Max checks the maximum value of a number.
+func (b *FieldBuilder) Max(max float64, msg ...string) *FieldBuilder {
+	return b.Validate(Max(max), msg...)
+}
+
+// GreaterThan checks that a number or decimal string is strictly greater than min.
+func (b *FieldBuilder) GreaterThan(min float64, msg ...string) *FieldBuilder {
+	return b.Validate(GreaterThan(min), msg...)
+}
+
+// LessThan checks that a number or decimal string is strictly less than max.
+func (b *FieldBuilder) LessThan(max float64, msg ...string) *FieldBuilder {
+	return b.Validate(LessThan(max), msg...)
+}
+
+// Between checks that a number or decimal string is between min and max inclusive.
+func (b *FieldBuilder) Between(min, max float64, msg ...string) *FieldBuilder {
+	return b.Validate(Between(min, max), msg...)
+}
+
+// MaxDecimalPlaces checks the number of decimal places of a number or decimal string.
+func (b *FieldBuilder) MaxDecimalPlaces(places int, msg ...string) *FieldBuilder {
+	return b.Validate(MaxDecimalPlaces(places), msg...)
+}
+
+// MultipleOf checks that a number or decimal string is a multiple of step.
+func (b *FieldBuilder) MultipleOf(step float64, msg ...string) *FieldBuilder {
+	return b.Validate(MultipleOf(step), msg...)
+}
+
+// Precision checks that a number or decimal string fits a NUMERIC(total, scale) column.
+func (b *FieldBuilder) Precision(total, scale int, msg ...string) *FieldBuilder {
+	return b.Validate(Precision(total, scale), msg...)
+}
+
+// EqualsField checks that the value equals another field.
+func (b *FieldBuilder) EqualsField(other string, msg ...string) *FieldBuilder {
+	return b.CrossField(EqualsField(other), msg...)
+}
+
+// GtField checks that the value is greater than another field.
+func (b *FieldBuilder) GtField(other string, msg ...string) *FieldBuilder {
+	return b.CrossField(GtField(other), msg...)
+}
+
+// LtField checks that the value is less than another field.
+func (b *FieldBuilder) LtField(other string, msg ...string) *FieldBuilder {
+	return b.CrossField(LtField(other), msg...)
+}
+
+// RequiredIf makes the field optional unless another field equals one of the given values.
+func (b *FieldBuilder) RequiredIf(other string, values ...interface{}) *FieldBuilder {
+	return b.Optional().CrossField(RequiredIf(other, values...))
+}
+
+// RequiredUnless makes the field optional when another field equals one of the given values.
+func (b *FieldBuilder) RequiredUnless(other string, values ...interface{}) *FieldBuilder {
+	return b.Optional().CrossField(RequiredUnless(other, values...))
+}
+
+// RequiredWith makes the field optional unless any of the other fields is present.
+func (b *FieldBuilder) RequiredWith(others ...string) *FieldBuilder {
+	return b.Optional().CrossField(RequiredWith(others...))
+}
+
+// ExcludedIf rejects the field when another field equals one of the given values.
+func (b *FieldBuilder) ExcludedIf(other string, values ...interface{}) *FieldBuilder {
+	return b.CrossField(ExcludedIf(other, values...))
+}
@@ -0,0 +1,84 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder(t *testing.T) {
+	options := Object(
+		Field("email").Required().Trim().ToLower().Email("Invalid email"),
+		Field("password").MinLength(6, "Password must be at least 6 characters"),
+		Field("password_confirmation").EqualsField("password", "Passwords do not match"),
+		Field("age").Optional().Int().Min(18),
+		Field("address").Object(
+			Field("street").NotEmpty("Street is required"),
+			Field("zip").Optional().Length(5, 5),
+		),
+		Field("items").Optional().ArrayOf(
+			Field("sku").Trim().Alphanumeric("Invalid SKU"),
+		),
+		ValidationOption{
+			Key:        "role",
+			IsOptional: true,
+			Validators: []Validator{CreateValidator(IsIn("admin", "user"), "Invalid role")},
+		},
+	)
+
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"email":                 "  USER@Example.com ",
+			"password":              "password123",
+			"password_confirmation": "password123",
+			"address":               map[string]interface{}{"street": "Main St"},
+			"items":                 []interface{}{map[string]interface{}{"sku": " A1 "}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(body map[string]interface{})
+		error  string
+	}{
+		{"valid input", func(body map[string]interface{}) {}, ""},
+		{"required field", func(body map[string]interface{}) { delete(body, "email") }, "email is required"},
+		{"custom message", func(body map[string]interface{}) { body["email"] = "invalid" }, "Invalid email"},
+		{"cross field", func(body map[string]interface{}) { body["password_confirmation"] = "other" }, "Passwords do not match"},
+		{"optional field", func(body map[string]interface{}) { body["age"] = 16 }, "value must be greater than or equal to 18"},
+		{"nested object", func(body map[string]interface{}) { body["address"] = map[string]interface{}{"street": ""} }, "Street is required"},
+		{"array of objects", func(body map[string]interface{}) {
+			body["items"] = []interface{}{map[string]interface{}{"sku": "A-1"}}
+		}, "Invalid SKU"},
+		{"raw option", func(body map[string]interface{}) { body["role"] = "root" }, "Invalid role"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body := valid()
+			test.modify(body)
+			err := Validate(body, options)
+			if test.error == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error)
+			}
+		})
+	}
+
+	t.Run("transformers update the body", func(t *testing.T) {
+		body := valid()
+		require.NoError(t, Validate(body, options))
+		require.Equal(t, "user@example.com", body["email"])
+		require.Equal(t, "A1", body["items"].([]interface{})[0].(map[string]interface{})["sku"])
+	})
+
+	t.Run("compiles to validation options", func(t *testing.T) {
+		option := Field("name").Optional().Trim().NotEmpty("Name is required").Option()
+		require.Equal(t, "name", option.Key)
+		require.True(t, option.IsOptional)
+		require.Len(t, option.Transformers, 1)
+		require.Len(t, option.Validators, 1)
+		require.Equal(t, "Name is required", option.Validators[0].Message)
+	})
+}
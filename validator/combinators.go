@@ -0,0 +1,77 @@
+package validator
+
+import "errors"
+
+// AllOf checks that the value passes every validator, returning the first failure as is.
+func AllOf(validators ...ValidatorFunc) ValidatorFunc {
+	return func(value interface{}) error {
+		for _, fn := range validators {
+			if err := fn(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// AnyOf checks that the value passes at least one of the validators.
+// When none does, the failure lists the failures of every alternative in its "errors" parameter,
+// as ValidationErrors, and unwraps to them. AnyOf without validators rejects every value.
+func AnyOf(validators ...ValidatorFunc) ValidatorFunc {
+	return func(value interface{}) error {
+		errs := make(ValidationErrors, 0, len(validators))
+		for _, fn := range validators {
+			err := fn(value)
+			if err == nil {
+				return nil
+			}
+			if isInternal(err) {
+				return err
+			}
+			errs = append(errs, err)
+		}
+		e := invalid("AnyOf", "any_of", value, Params{"errors": errs})
+		e.Err = errors.Join(errs...)
+		return e
+	}
+}
+
+// Not checks that the value fails the validator, reporting msg when it passes, or a generic message when msg is empty.
+func Not(validator ValidatorFunc, msg string) ValidatorFunc {
+	return func(value interface{}) error {
+		err := validator(value)
+		if err == nil {
+			if msg != "" {
+				return &ValidationError{Rule: "Not", Code: CodeInvalid, Value: value, Message: msg}
+			}
+			return invalid("Not", "not", value, nil)
+		}
+		if isInternal(err) {
+			return err
+		}
+		return nil
+	}
+}
+
+// Optional applies the validator to values that are not nil, e.g. Each(Optional(IsEmail)) accepts null elements.
+func Optional(validator ValidatorFunc) ValidatorFunc {
+	return func(value interface{}) error {
+		if value == nil {
+			return nil
+		}
+		return validator(value)
+	}
+}
+
+// When applies then to the values matching pred and otherwise to the others, otherwise may be nil to accept them.
+func When(pred func(value interface{}) bool, then, otherwise ValidatorFunc) ValidatorFunc {
+	return func(value interface{}) error {
+		if pred(value) {
+			return then(value)
+		}
+		if otherwise != nil {
+			return otherwise(value)
+		}
+		return nil
+	}
+}
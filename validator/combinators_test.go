@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCombinators(t *testing.T) {
+	isString := func(value interface{}) bool {
+		_, ok := value.(string)
+		return ok
+	}
+	tests := []struct {
+		name  string
+		fn    ValidatorFunc
+		input interface{}
+		error error
+	}{
+		{"all of", AllOf(IsString, MinLength(2)), "ab", nil},
+		{"all of with a failure", AllOf(IsString, MinLength(2), MaxLength(1)), "ab", errors.New("value must be at most 1 characters long")},
+		{"any of first", AnyOf(IsEmail, IsUUID), "user@example.com", nil},
+		{"any of second", AnyOf(IsEmail, IsUUID), "123e4567-e89b-12d3-a456-426614174000", nil},
+		{"none of", AnyOf(IsEmail, IsUUID), "user", errors.New("value matches none of the alternatives: value is not a valid email address; value is not a valid UUID")},
+		{"any of without validators", AnyOf(), "user", errors.New("value matches none of the alternatives: ")},
+		{"not", Not(IsIn("root", "admin"), "reserved username"), "alice", nil},
+		{"not with a match", Not(IsIn("root", "admin"), "reserved username"), "root", errors.New("reserved username")},
+		{"not with the default message", Not(IsIn("root"), ""), "root", errors.New("value is not allowed")},
+		{"optional nil", Optional(IsEmail), nil, nil},
+		{"optional value", Optional(IsEmail), "user", errors.New("value is not a valid email address")},
+		{"when then", When(isString, MinLength(3), Min(0)), "ab", errors.New("value must be at least 3 characters long")},
+		{"when otherwise", When(isString, MinLength(3), Min(0)), -1, errors.New("value must be greater than or equal to 0")},
+		{"when without otherwise", When(isString, MinLength(3), nil), -1, nil},
+		{"each of optional", Each(Optional(IsEmail)), []interface{}{"user@example.com", nil}, nil},
+		{"each of any of", Each(AnyOf(IsInt, IsString)), []interface{}{1, "a", true}, errors.New("element at index 2: value matches none of the alternatives: value must be an integer; value must be a string")},
+		{"nested combinators", AllOf(Not(IsIn(""), ""), AnyOf(AllOf(IsString, MinLength(5)), IsInt)), "abc", errors.New("value matches none of the alternatives: value must be at least 5 characters long; value must be an integer")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.fn(test.input)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
+		})
+	}
+
+	t.Run("errors of the alternatives", func(t *testing.T) {
+		options := Object(Field("contact").Validate(AnyOf(IsEmail, Regex(`^\+[0-9]+$`))))
+		err := ValidateAll(map[string]interface{}{"contact": "nope"}, options)
+
+		var errs ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 1)
+		e := errs[0].(*ValidationError)
+		require.Equal(t, "contact", e.Field)
+		require.Equal(t, "AnyOf", e.Rule)
+		require.Equal(t, CodeInvalid, e.Code)
+
+		alternatives := e.Params["errors"].(ValidationErrors)
+		require.Len(t, alternatives, 2)
+		require.Equal(t, "IsEmail", alternatives[0].(*ValidationError).Rule)
+		require.Equal(t, "Regex", alternatives[1].(*ValidationError).Rule)
+
+		var pattern *ValidationError
+		require.ErrorAs(t, e.Err, &pattern)
+		require.Equal(t, "IsEmail", pattern.Rule)
+	})
+
+	t.Run("localized alternatives", func(t *testing.T) {
+		options := Object(Field("id").Validate(AnyOf(IsInt, IsUUID)))
+		err := ValidateContext(context.Background(), map[string]interface{}{"id": "x"}, options, WithLocale("fr"))
+		require.EqualError(t, err, "la valeur ne correspond à aucune des alternatives : la valeur doit être un entier; la valeur n'est pas un UUID valide")
+	})
+}
@@ -0,0 +1,213 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Concurrency makes ValidateContext run expensive validators on a pool of n goroutines.
+// Failures are still reported in declaration order, and in first-error mode the first failure cancels the
+// context of every expensive validator declared after it. A field's expensive validators run once its other
+// validators and nested options passed, and cross-field validators always run sequentially. A panicking expensive
+// validator is reported as an *InternalError.
+func Concurrency(n int) RunOption {
+	return func(v *validation) {
+		v.concurrency = n
+	}
+}
+
+// job holds the expensive validators of a single field.
+type job struct {
+	order    int // Position of the field in declaration order
+	orderEnd int // First position after the field's nested options
+	errIndex int // Number of failures recorded before the field
+	errEnd   int // Number of failures recorded once the field's nested options were validated
+	path     string
+	value    interface{}
+	tasks    []*task
+}
+
+// task runs a single expensive validator.
+type task struct {
+	job       *job
+	index     int
+	ctx       context.Context
+	cancel    context.CancelFunc
+	validator Validator
+	err       error
+}
+
+// after checks if the task is declared after the task at (order, index).
+func (t *task) after(order, index int) bool {
+	return t.job.order > order || (t.job.order == order && t.index > index)
+}
+
+// pool runs tasks on a bounded number of goroutines.
+type pool struct {
+	size       int
+	collectAll bool
+	queue      chan *task
+	wg         sync.WaitGroup
+	mu         sync.Mutex
+	tasks      []*task
+	failed     atomic.Bool
+	failOrder  int
+	failIndex  int
+}
+
+// newPool creates a pool of size goroutines, started on first use.
+func newPool(size int, collectAll bool) *pool {
+	return &pool{size: size, collectAll: collectAll}
+}
+
+// submit queues the tasks of a job.
+func (p *pool) submit(ctx context.Context, j *job) {
+	if p.queue == nil {
+		p.queue = make(chan *task)
+		for range p.size {
+			p.wg.Add(1)
+			go p.work()
+		}
+	}
+	for _, t := range j.tasks {
+		t.ctx, t.cancel = context.WithCancel(ctx)
+		p.mu.Lock()
+		p.tasks = append(p.tasks, t)
+		if p.failed.Load() && t.after(p.failOrder, p.failIndex) {
+			t.cancel()
+		}
+		p.mu.Unlock()
+		p.queue <- t
+	}
+}
+
+// work runs queued tasks until the queue is closed.
+func (p *pool) work() {
+	defer p.wg.Done()
+	for t := range p.queue {
+		if err := t.ctx.Err(); err != nil {
+			t.err = err
+		} else {
+			t.err = t.run()
+		}
+		if t.err != nil && !p.collectAll {
+			p.cancelAfter(t)
+		}
+	}
+}
+
+// run runs the validator of t. A panic can't reach the caller from a worker goroutine, where it would crash the process,
+// so it is reported as an *InternalError instead.
+func (t *task) run() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = Internal(fmt.Errorf("validator: panic in expensive validator of %s: %v", t.job.path, r))
+		}
+	}()
+	return t.validator.run(t.ctx, t.job.value, FieldContext{})
+}
+
+// cancelAfter cancels every task declared after a failing one.
+func (p *pool) cancelAfter(failed *task) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failed.Load() && failed.after(p.failOrder, p.failIndex) {
+		return
+	}
+	p.failed.Store(true)
+	p.failOrder, p.failIndex = failed.job.order, failed.index
+	for _, t := range p.tasks {
+		if t.after(p.failOrder, p.failIndex) {
+			t.cancel()
+		}
+	}
+}
+
+// wait waits for every queued task to finish.
+func (p *pool) wait() {
+	if p.queue == nil {
+		return
+	}
+	close(p.queue)
+	p.wg.Wait()
+	for _, t := range p.tasks {
+		t.cancel()
+	}
+}
+
+// deferExpensive splits the expensive validators of the field at path off into a job, returning the other validators.
+// The job's tasks are submitted by finishJob once the field's value is final.
+func (v *validation) deferExpensive(path string, validators []Validator) ([]Validator, *job) {
+	if v.pool == nil {
+		return validators, nil
+	}
+	var cheap []Validator
+	j := &job{order: v.order, errIndex: len(v.errs), path: path}
+	for _, validator := range validators {
+		if validator.Expensive && validator.CrossFunc == nil {
+			j.tasks = append(j.tasks, &task{job: j, index: len(j.tasks), validator: validator})
+		} else {
+			cheap = append(cheap, validator)
+		}
+	}
+	if len(j.tasks) == 0 {
+		return validators, nil
+	}
+	v.order++
+	v.jobs = append(v.jobs, j)
+	return cheap, j
+}
+
+// finishJob submits the tasks of a job once its field and nested options were validated, running them with ctx.
+func (v *validation) finishJob(ctx context.Context, j *job, value interface{}) {
+	j.value = value
+	j.orderEnd = v.order
+	j.errEnd = len(v.errs)
+	v.pool.submit(ctx, j)
+}
+
+// stopped checks if an expensive validator already failed in first-error mode, so the rest of the body can be skipped.
+func (v *validation) stopped() bool {
+	return v.pool != nil && !v.collectAll && v.pool.failed.Load()
+}
+
+// merge waits for the expensive validators and merges their failures with the others in declaration order.
+func (v *validation) merge() {
+	v.pool.wait()
+	if v.err != nil {
+		return
+	}
+
+	merged := &validation{ctx: v.ctx, collectAll: v.collectAll}
+	skipErrs, skipOrder := 0, -1
+	next := 0
+	for i := 0; i <= len(v.errs); i++ {
+		for next < len(v.jobs) && v.jobs[next].errIndex == i {
+			j := v.jobs[next]
+			next++
+			if j.order < skipOrder {
+				continue
+			}
+			for _, t := range j.tasks {
+				if t.err == nil {
+					continue
+				}
+				if !merged.failValidator(j.path, j.value, t.validator, t.err) {
+					v.errs, v.err = merged.errs, merged.err
+					return
+				}
+				// Like sequential validation, the field's remaining validators and nested failures are dropped
+				skipErrs, skipOrder = j.errEnd, j.orderEnd
+				break
+			}
+		}
+		if i < len(v.errs) && i >= skipErrs {
+			if !merged.fail(v.errs[i].(*ValidationError)) {
+				break
+			}
+		}
+	}
+	v.errs, v.err = merged.errs, merged.err
+}
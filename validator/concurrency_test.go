@@ -0,0 +1,145 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// lookup simulates an expensive validator failing after delay when the value equals invalid.
+func lookup(delay time.Duration, invalid string) ContextValidatorFunc {
+	return func(ctx context.Context, value interface{}) error {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if value == invalid {
+			return errors.New(invalid + " does not exist")
+		}
+		return nil
+	}
+}
+
+func TestConcurrency(t *testing.T) {
+	t.Run("expensive validators run concurrently", func(t *testing.T) {
+		var started sync.WaitGroup
+		started.Add(3)
+		barrier := func(ctx context.Context, value interface{}) error {
+			started.Done()
+			done := make(chan struct{})
+			go func() {
+				started.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+				return nil
+			case <-time.After(time.Second):
+				return errors.New("validators did not run concurrently")
+			}
+		}
+		options := Object(
+			Field("a").ValidateContext(barrier).Expensive(),
+			Field("b").ValidateContext(barrier).Expensive(),
+			Field("c").ValidateContext(barrier).Expensive(),
+		)
+		body := map[string]interface{}{"a": 1, "b": 2, "c": 3}
+		require.NoError(t, ValidateContext(context.Background(), body, options, Concurrency(3)))
+	})
+
+	options := Object(
+		Field("coupon").NotEmpty("Coupon is required").ValidateContext(lookup(50*time.Millisecond, "slow")).Expensive(),
+		Field("store").Object(
+			Field("id").ValidateContext(lookup(0, "fast")).Expensive(),
+		),
+		Field("user").ValidateContext(lookup(0, "fast")).Expensive(),
+		Field("note").Optional().MaxLength(5, "Note is too long"),
+	)
+
+	t.Run("failures are merged in declaration order", func(t *testing.T) {
+		body := map[string]interface{}{
+			"coupon": "slow",
+			"store":  map[string]interface{}{"id": "fast"},
+			"user":   "fast",
+			"note":   "too long",
+		}
+		err := ValidateContext(context.Background(), body, options, Concurrency(4), CollectAll())
+		require.EqualError(t, err, "slow does not exist; fast does not exist; fast does not exist; Note is too long")
+
+		var errs ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		require.Equal(t, "store.id", errs[1].(*ValidationError).Field)
+	})
+
+	t.Run("first error mode keeps the first failure in declaration order", func(t *testing.T) {
+		body := map[string]interface{}{
+			"coupon": "slow",
+			"store":  map[string]interface{}{"id": "fast"},
+			"user":   "valid",
+		}
+		err := ValidateContext(context.Background(), body, options, Concurrency(4))
+		require.EqualError(t, err, "slow does not exist")
+	})
+
+	t.Run("first error cancels later validators", func(t *testing.T) {
+		started, cancelled := make(chan struct{}), make(chan struct{})
+		options := Object(
+			Field("a").ValidateContext(func(ctx context.Context, value interface{}) error {
+				<-started
+				return errors.New("a is invalid")
+			}).Expensive(),
+			Field("b").ValidateContext(func(ctx context.Context, value interface{}) error {
+				close(started)
+				<-ctx.Done()
+				close(cancelled)
+				return ctx.Err()
+			}).Expensive(),
+		)
+		err := ValidateContext(context.Background(), map[string]interface{}{"a": "x", "b": "y"}, options, Concurrency(2))
+		require.EqualError(t, err, "a is invalid")
+		<-cancelled
+	})
+
+	t.Run("cheap validators run first", func(t *testing.T) {
+		body := map[string]interface{}{"coupon": "", "store": map[string]interface{}{"id": "ok"}, "user": "ok"}
+		err := ValidateContext(context.Background(), body, options, Concurrency(2), CollectAll())
+		require.EqualError(t, err, "Coupon is required")
+	})
+
+	t.Run("internal errors abort validation", func(t *testing.T) {
+		options := Object(
+			Field("a").ValidateContext(func(ctx context.Context, value interface{}) error {
+				return Internal(errors.New("connection refused"))
+			}).Expensive(),
+			Field("b").MinLength(3),
+		)
+		err := ValidateContext(context.Background(), map[string]interface{}{"a": "x", "b": "x"}, options, Concurrency(2), CollectAll())
+		var internal *InternalError
+		require.ErrorAs(t, err, &internal)
+	})
+
+	t.Run("panics are internal errors", func(t *testing.T) {
+		var repository map[string]*struct{ ID string }
+		options := Object(
+			Field("user").Object(
+				Field("id").ValidateContext(func(ctx context.Context, value interface{}) error {
+					if repository[value.(string)].ID == "" {
+						return errors.New("user does not exist")
+					}
+					return nil
+				}).Expensive(),
+			),
+			Field("b").MinLength(3),
+		)
+		body := map[string]interface{}{"user": map[string]interface{}{"id": "x"}, "b": "abc"}
+		err := ValidateContext(context.Background(), body, options, Concurrency(2))
+		var internal *InternalError
+		require.ErrorAs(t, err, &internal)
+		require.ErrorContains(t, err, "validator: panic in expensive validator of user.id: runtime error: invalid memory address or nil pointer dereference")
+	})
+}
@@ -0,0 +1,220 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// lookupField resolves a field path relative to the field's siblings, e.g. "password" or "address.country".
+// Paths starting with "$." are resolved from the root of the request body, e.g. "$.user.country".
+func lookupField(field FieldContext, path string) (interface{}, bool) {
+	current := field.Parent
+	if strings.HasPrefix(path, "$.") {
+		current = field.Root
+		path = strings.TrimPrefix(path, "$.")
+	}
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		value, exists := current[part]
+		if !exists {
+			return nil, false
+		}
+		if i == len(parts)-1 {
+			return value, true
+		}
+		next, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+	return nil, false
+}
+
+// fieldMatches checks if the field at path is present and equal to one of the given values.
+func fieldMatches(field FieldContext, path string, values []interface{}) bool {
+	other, exists := lookupField(field, path)
+	if !exists {
+		return false
+	}
+	for _, value := range values {
+		if equalValues(other, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalValues reports whether a and b are equal, comparing numbers by value so that 1 matches the float64 1 of decoded JSON.
+func equalValues(a, b interface{}) bool {
+	if _, ok := toNumber(a); ok {
+		c, ok := compareNumbers(a, b)
+		return ok && c == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// toTime parses time.Time values and date strings in RFC 3339 or YYYY-MM-DD format.
+func toTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// compareValues compares two numbers, dates or strings and returns -1, 0 or 1.
+func compareValues(a, b interface{}) (int, error) {
+	if _, ok := toNumber(a); ok {
+		c, ok := compareNumbers(a, b)
+		if !ok {
+			return 0, errors.New("values are not comparable")
+		}
+		return c, nil
+	}
+	if x, ok := toTime(a); ok {
+		if y, ok := toTime(b); ok {
+			return x.Compare(y), nil
+		}
+	}
+	x, ok := a.(string)
+	y, ok2 := b.(string)
+	if !ok || !ok2 {
+		return 0, errors.New("values are not comparable")
+	}
+	return strings.Compare(x, y), nil
+}
+
+// compareField builds a cross-field validator comparing the value with another field.
+// An absent or null value is left to the required and nullable checks of the field.
+func compareField(rule, key, other string, accept func(int) bool) CrossFieldFunc {
+	return func(value interface{}, field FieldContext) error {
+		if !field.Exists || value == nil {
+			return nil
+		}
+		otherValue, exists := lookupField(field, other)
+		if !exists {
+			return invalid(rule, "comparison_required", value, Params{"other": other})
+		}
+		cmp, err := compareValues(value, otherValue)
+		if err != nil {
+			return invalidType(rule, "not_comparable", value, Params{"other": other})
+		}
+		if !accept(cmp) {
+			return invalid(rule, key, value, Params{"other": other})
+		}
+		return nil
+	}
+}
+
+// EqualsField checks if a value is equal to the value of another field, comparing numbers by value.
+// An absent or null value is left to the required and nullable checks of the field.
+func EqualsField(other string) CrossFieldFunc {
+	return func(value interface{}, field FieldContext) error {
+		if !field.Exists || value == nil {
+			return nil
+		}
+		otherValue, _ := lookupField(field, other)
+		if !equalValues(value, otherValue) {
+			return invalid("EqualsField", "equals_field", value, Params{"other": other})
+		}
+		return nil
+	}
+}
+
+// NotEqualsField checks if a value differs from the value of another field, comparing numbers by value.
+// An absent or null value is left to the required and nullable checks of the field.
+func NotEqualsField(other string) CrossFieldFunc {
+	return func(value interface{}, field FieldContext) error {
+		if !field.Exists || value == nil {
+			return nil
+		}
+		otherValue, _ := lookupField(field, other)
+		if equalValues(value, otherValue) {
+			return invalid("NotEqualsField", "not_equals_field", value, Params{"other": other})
+		}
+		return nil
+	}
+}
+
+// GtField checks if a number, date or string is greater than the value of another field.
+func GtField(other string) CrossFieldFunc {
+	return compareField("GtField", "gt_field", other, func(cmp int) bool { return cmp > 0 })
+}
+
+// GteField checks if a number, date or string is greater than or equal to the value of another field.
+func GteField(other string) CrossFieldFunc {
+	return compareField("GteField", "gte_field", other, func(cmp int) bool { return cmp >= 0 })
+}
+
+// LtField checks if a number, date or string is less than the value of another field.
+func LtField(other string) CrossFieldFunc {
+	return compareField("LtField", "lt_field", other, func(cmp int) bool { return cmp < 0 })
+}
+
+// LteField checks if a number, date or string is less than or equal to the value of another field.
+func LteField(other string) CrossFieldFunc {
+	return compareField("LteField", "lte_field", other, func(cmp int) bool { return cmp <= 0 })
+}
+
+// requiredError reports a field that is conditionally required but missing.
+func requiredError(rule, key string, field FieldContext, params Params) error {
+	params["field"] = field.Key
+	return newError(rule, CodeRequired, key, nil, params)
+}
+
+// RequiredIf checks that the field is present when another field equals one of the given values, comparing numbers by value.
+// Use it on an optional field so it is only enforced when the condition holds.
+func RequiredIf(other string, values ...interface{}) CrossFieldFunc {
+	return func(value interface{}, field FieldContext) error {
+		if (!field.Exists || value == nil) && fieldMatches(field, other, values) {
+			return requiredError("RequiredIf", "required_if", field, Params{"other": other, "values": values})
+		}
+		return nil
+	}
+}
+
+// RequiredUnless checks that the field is present unless another field equals one of the given values.
+// Use it on an optional field so it is only enforced when the condition holds.
+func RequiredUnless(other string, values ...interface{}) CrossFieldFunc {
+	return func(value interface{}, field FieldContext) error {
+		if (!field.Exists || value == nil) && !fieldMatches(field, other, values) {
+			return requiredError("RequiredUnless", "required_unless", field, Params{"other": other, "values": values})
+		}
+		return nil
+	}
+}
+
+// RequiredWith checks that the field is present when any of the other fields is present.
+// Use it on an optional field so it is only enforced when the condition holds.
+func RequiredWith(others ...string) CrossFieldFunc {
+	return func(value interface{}, field FieldContext) error {
+		if field.Exists && value != nil {
+			return nil
+		}
+		for _, other := range others {
+			if _, exists := lookupField(field, other); exists {
+				return requiredError("RequiredWith", "required_with", field, Params{"other": other})
+			}
+		}
+		return nil
+	}
+}
+
+// ExcludedIf checks that the field is absent when another field equals one of the given values.
+func ExcludedIf(other string, values ...interface{}) CrossFieldFunc {
+	return func(value interface{}, field FieldContext) error {
+		if field.Exists && fieldMatches(field, other, values) {
+			return invalid("ExcludedIf", "excluded_if", value, Params{"field": field.Key, "other": other, "values": values})
+		}
+		return nil
+	}
+}
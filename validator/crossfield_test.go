@@ -0,0 +1,222 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEqualsField(t *testing.T) {
+	options := []ValidationOption{
+		{Key: "password"},
+		{
+			Key: "password_confirmation",
+			Validators: []Validator{
+				CreateCrossFieldValidator(EqualsField("password"), "Passwords do not match"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		input map[string]interface{}
+		error error
+	}{
+		{"matching", map[string]interface{}{"password": "secret", "password_confirmation": "secret"}, nil},
+		{"not matching", map[string]interface{}{"password": "secret", "password_confirmation": "other"}, errors.New("Passwords do not match")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Validate(test.input, options)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
+		})
+	}
+}
+
+func TestCompareFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		fn    CrossFieldFunc
+		input map[string]interface{}
+		error error
+	}{
+		{"date after", GtField("start_date"), map[string]interface{}{"start_date": "2024-01-01", "value": "2024-02-01"}, nil},
+		{"date before", GtField("start_date"), map[string]interface{}{"start_date": "2024-01-01", "value": "2023-12-31"}, errors.New("value must be greater than start_date")},
+		{"number greater or equal", GteField("min"), map[string]interface{}{"min": 5, "value": 5.0}, nil},
+		{"number less", LtField("max"), map[string]interface{}{"max": 10, "value": 12}, errors.New("value must be less than max")},
+		{"number less or equal", LteField("max"), map[string]interface{}{"max": 10, "value": 10}, nil},
+		{"large integers", GtField("min"), map[string]interface{}{"min": uint64(1<<63 + 1), "value": json.Number("9223372036854775810")}, nil},
+		{"large integers equal", GtField("min"), map[string]interface{}{"min": uint64(1<<63 + 1), "value": json.Number("9223372036854775809")}, errors.New("value must be greater than min")},
+		{"missing other field", GtField("start_date"), map[string]interface{}{"value": "2024-02-01"}, errors.New("start_date is required for comparison")},
+		{"not comparable", GtField("start_date"), map[string]interface{}{"start_date": "2024-01-01", "value": 3}, errors.New("value cannot be compared with start_date")},
+		{"not equal", NotEqualsField("old"), map[string]interface{}{"old": "a", "value": "a"}, errors.New("value must not be equal to old")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			field := FieldContext{Key: "value", Exists: true, Parent: test.input, Root: test.input}
+			err := test.fn(test.input["value"], field)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
+		})
+	}
+}
+
+func TestConditionalRequired(t *testing.T) {
+	options := []ValidationOption{
+		{Key: "country"},
+		{
+			Key:        "vat_number",
+			IsOptional: true,
+			Validators: []Validator{
+				CreateCrossFieldValidator(RequiredIf("country", "FR", "DE"), ""),
+				CreateValidator(IsAlphanumeric, "Invalid VAT number"),
+			},
+		},
+		{
+			Key:        "phone",
+			IsOptional: true,
+			Validators: []Validator{
+				CreateCrossFieldValidator(RequiredUnless("$.contact.email_only", true), ""),
+			},
+		},
+		{
+			Key:        "zip",
+			IsOptional: true,
+			Validators: []Validator{
+				CreateCrossFieldValidator(RequiredWith("street"), ""),
+			},
+		},
+		{
+			Key:        "state",
+			IsOptional: true,
+			Validators: []Validator{
+				CreateCrossFieldValidator(ExcludedIf("country", "FR"), ""),
+			},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		input map[string]interface{}
+		error error
+	}{
+		{"not required", map[string]interface{}{"country": "US", "phone": "1", "state": "CA"}, nil},
+		{"required if", map[string]interface{}{"country": "FR", "phone": "1"}, errors.New("vat_number is required when country is one of [FR DE]")},
+		{"required if satisfied", map[string]interface{}{"country": "DE", "vat_number": "DE123", "phone": "1"}, nil},
+		{"regular validators still run", map[string]interface{}{"country": "DE", "vat_number": "DE-123", "phone": "1"}, errors.New("Invalid VAT number")},
+		{"required unless", map[string]interface{}{"country": "US"}, errors.New("phone is required unless $.contact.email_only is one of [true]")},
+		{"required unless satisfied", map[string]interface{}{"country": "US", "contact": map[string]interface{}{"email_only": true}}, nil},
+		{"required with", map[string]interface{}{"country": "US", "phone": "1", "street": "Main St"}, errors.New("zip is required when street is present")},
+		{"excluded if", map[string]interface{}{"country": "FR", "vat_number": "FR1", "phone": "1", "state": "IDF"}, errors.New("state must not be present when country is one of [FR]")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Validate(test.input, options)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
+		})
+	}
+
+	t.Run("reports a required code", func(t *testing.T) {
+		err := Validate(map[string]interface{}{"country": "FR", "phone": "1"}, options)
+		var ve *ValidationError
+		require.ErrorAs(t, err, &ve)
+		require.Equal(t, "vat_number", ve.Field)
+		require.Equal(t, "RequiredIf", ve.Rule)
+		require.Equal(t, CodeRequired, ve.Code)
+	})
+}
+
+func TestCrossFieldAbsentValues(t *testing.T) {
+	options := Object(
+		Field("start_date"),
+		Field("end_date").Optional().Nullable().GtField("start_date"),
+		Field("confirmation").Optional().Nullable().CrossField(EqualsField("start_date")),
+	)
+
+	tests := []struct {
+		name  string
+		input map[string]interface{}
+		error string
+	}{
+		{"absent", map[string]interface{}{"start_date": "2024-01-01"}, ""},
+		{"null", map[string]interface{}{"start_date": "2024-01-01", "end_date": nil, "confirmation": nil}, ""},
+		{"present", map[string]interface{}{"start_date": "2024-01-01", "end_date": "2023-12-31"}, "value must be greater than start_date"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Validate(test.input, options)
+			if test.error == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error)
+			}
+		})
+	}
+}
+
+func TestRequiredIfNumbers(t *testing.T) {
+	options := Object(
+		Field("kind"),
+		Field("reference").Optional().CrossField(RequiredIf("kind", 1)),
+		Field("quantity").Optional().CrossField(EqualsField("kind")),
+	)
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"kind": 1, "quantity": 1.0}`), &body))
+
+	err := Validate(body, options)
+	require.EqualError(t, err, "reference is required when kind is one of [1]")
+
+	body["reference"] = "R-1"
+	require.NoError(t, Validate(body, options))
+}
+
+func TestRootPathsInElements(t *testing.T) {
+	vat := Field("vat").Optional().CrossField(RequiredIf("$.country", "FR"))
+	registry := NewRegistry().Define("Line", vat)
+
+	tests := []struct {
+		name  string
+		field FieldSpec
+		input interface{}
+		path  string
+	}{
+		{"nested object", Field("items").Object(vat), map[string]interface{}{}, "items.vat"},
+		{"array of", Field("items").ArrayOf(vat), []interface{}{map[string]interface{}{}}, "items[0].vat"},
+		{"array of ref", Field("items").ArrayOfRef("Line"), []interface{}{map[string]interface{}{}}, "items[0].vat"},
+		{"each with options context", Field("items").ValidateContext(EachWithOptionsContext(Object(vat))), []interface{}{map[string]interface{}{}}, "items[0].vat"},
+		{"map of", Field("items").MapOf(nil, Field("").Object(vat)), map[string]interface{}{"a": map[string]interface{}{}}, "items.a.vat"},
+		{"nested arrays", Field("items").ArrayOf(Field("lines").ArrayOf(vat)), []interface{}{map[string]interface{}{"lines": []interface{}{map[string]interface{}{}}}}, "items[0].lines[0].vat"},
+		{"union in array", Field("items").ArrayOf(OneOf(Case("line", vat))), []interface{}{map[string]interface{}{}}, "items[0]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body := map[string]interface{}{"country": "FR", "items": test.input}
+			err := ValidateContext(context.Background(), body, Object(Field("country"), test.field), WithRegistry(registry))
+			var ve *ValidationError
+			require.ErrorAs(t, err, &ve)
+			require.Equal(t, test.path, ve.Field)
+
+			body["country"] = "US"
+			require.NoError(t, ValidateContext(context.Background(), body, Object(Field("country"), test.field), WithRegistry(registry)))
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package validator
+
+// defaultValue returns the value written into the body for an absent field with the given default.
+// Generator funcs are called each time, and maps and slices are copied so bodies never share them.
+func defaultValue(def interface{}) interface{} {
+	if generate, ok := def.(func() interface{}); ok {
+		return generate()
+	}
+	return deepCopy(def)
+}
@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaults(t *testing.T) {
+	t.Run("absent fields take their default", func(t *testing.T) {
+		calls := 0
+		options := Object(
+			Field("role").Optional().Default("  USER ").Trim().ToLower().OneOf([]interface{}{"user", "admin"}),
+			Field("tags").Optional().Default([]interface{}{"new"}),
+			Field("id").Default(func() interface{} {
+				calls++
+				return calls
+			}),
+		)
+
+		body := map[string]interface{}{}
+		require.NoError(t, Validate(body, options))
+		require.Equal(t, map[string]interface{}{"role": "user", "tags": []interface{}{"new"}, "id": 1}, body)
+
+		// Generators run for every body, static maps and slices are copied
+		body["tags"].([]interface{})[0] = "changed"
+		other := map[string]interface{}{"role": "admin"}
+		require.NoError(t, Validate(other, options))
+		require.Equal(t, map[string]interface{}{"role": "admin", "tags": []interface{}{"new"}, "id": 2}, other)
+	})
+
+	t.Run("defaults are validated", func(t *testing.T) {
+		options := Object(Field("quantity").Optional().Default(0).Min(1, "Quantity must be positive"))
+		require.EqualError(t, Validate(map[string]interface{}{}, options), "Quantity must be positive")
+	})
+
+	t.Run("defaults apply in nested objects and array elements", func(t *testing.T) {
+		options := Object(
+			Field("address").Object(
+				Field("country").Optional().Default("FR"),
+			),
+			Field("items").ArrayOf(
+				Field("quantity").Optional().Default(1),
+			),
+		)
+		body := map[string]interface{}{
+			"address": map[string]interface{}{},
+			"items":   []interface{}{map[string]interface{}{}, map[string]interface{}{"quantity": 3}},
+		}
+		require.NoError(t, Validate(body, options))
+		require.Equal(t, map[string]interface{}{
+			"address": map[string]interface{}{"country": "FR"},
+			"items":   []interface{}{map[string]interface{}{"quantity": 1}, map[string]interface{}{"quantity": 3}},
+		}, body)
+	})
+
+	t.Run("cross-field validators see defaults of later fields", func(t *testing.T) {
+		options := Object(
+			Field("end").GtField("start"),
+			Field("start").Optional().Default(10),
+		)
+		require.EqualError(t, Validate(map[string]interface{}{"end": 5}, options), "value must be greater than start")
+	})
+
+	t.Run("nil objects are reported instead of written", func(t *testing.T) {
+		options := Object(Field("x").Optional().Default(1))
+		err := Validate(nil, options)
+		var e *ValidationError
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, "", e.Field)
+		require.Equal(t, CodeInvalidType, e.Code)
+		require.EqualError(t, err, "value is nil")
+
+		nested := Object(Field("address").Object(Field("country").Optional().Default("FR")))
+		err = Validate(map[string]interface{}{"address": map[string]interface{}(nil)}, nested)
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, "address", e.Field)
+	})
+}
@@ -0,0 +1,126 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// Error codes reported in ValidationError.Code.
+const (
+	CodeRequired    = "required"     // A required field is missing
+	CodeNull        = "null"         // A field that is not nullable is null
+	CodeInvalidType = "invalid_type" // The value has the wrong type, e.g. a nested object that is not a map
+	CodeInvalid     = "invalid"      // A validator rejected the value
+	CodeUnknownKey  = "unknown_key"  // The body holds a key no option declares, see RejectUnknownKeys
+)
+
+// ValidationError describes a single validation failure.
+type ValidationError struct {
+	Field   string      `json:"field"`            // Path to the field, e.g. "address.street" or "items[3].sku"
+	Rule    string      `json:"rule"`             // Name of the rule that failed, e.g. "required" or "IsEmail"
+	Code    string      `json:"code"`             // Machine-readable error code
+	Key     string      `json:"key,omitempty"`    // Message key used to translate built-in messages, see Catalog
+	Params  Params      `json:"params,omitempty"` // Parameters of the rule rendered into its message, e.g. {"min": 6}
+	Value   interface{} `json:"-"`                // Offending value, never serialized so responses don't echo submitted data
+	Message string      `json:"message"`          // Rendered, human-readable message
+	Err     error       `json:"-"`                // Underlying error returned by the validator, if any
+}
+
+// Params holds the parameters of a rule, e.g. Params{"min": 6}.
+type Params map[string]interface{}
+
+// Error returns the rendered message.
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the underlying error returned by the validator.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors is the list of failures collected by ValidateAll.
+type ValidationErrors []error
+
+// Error joins the messages of all collected failures.
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap exposes the collected failures to errors.Is and errors.As.
+func (e ValidationErrors) Unwrap() []error {
+	return e
+}
+
+// InternalError wraps an infrastructure failure, such as an unreachable database, returned by a context validator.
+// It aborts validation and is returned as is instead of being reported as a ValidationError.
+type InternalError struct {
+	Err error
+}
+
+// Error returns the message of the underlying error.
+func (e *InternalError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *InternalError) Unwrap() error {
+	return e.Err
+}
+
+// Internal marks err as an infrastructure failure rather than a validation failure.
+func Internal(err error) error {
+	return &InternalError{Err: err}
+}
+
+// isInternal checks if err aborts validation rather than reporting invalid input.
+func isInternal(err error) bool {
+	var internal *InternalError
+	return errors.As(err, &internal) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// joinPath appends a field path to its parent path, e.g. "address" + "street" or "items" + "[3].sku".
+func joinPath(parent, field string) string {
+	if parent == "" {
+		return field
+	}
+	if field == "" {
+		return parent
+	}
+	if strings.HasPrefix(field, "[") {
+		return parent + field
+	}
+	return parent + "." + field
+}
+
+// withPath returns a copy of err with its field path nested under parent.
+func withPath(parent string, err *ValidationError) *ValidationError {
+	e := *err
+	e.Field = joinPath(parent, e.Field)
+	return &e
+}
+
+// ruleName derives a rule name from a validator function, e.g. "IsEmail" or "MinLength".
+func ruleName(fn interface{}) string {
+	f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	if f == nil {
+		return ""
+	}
+	name := f.Name()
+	// Drop the import path and package name, then closure suffixes such as ".func1"
+	name = name[strings.LastIndex(name, "/")+1:]
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
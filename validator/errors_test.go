@@ -0,0 +1,108 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationErrorPaths(t *testing.T) {
+	options := []ValidationOption{
+		{
+			Key: "address",
+			Nested: []ValidationOption{
+				{
+					Key: "street",
+					Validators: []Validator{
+						CreateValidator(IsNotEmpty, ""),
+					},
+				},
+			},
+		},
+		{
+			Key: "tags",
+			Validators: []Validator{
+				CreateValidator(Each(IsString), ""),
+			},
+		},
+		{
+			Key: "items",
+			Validators: []Validator{
+				CreateValidator(EachWithOptions([]ValidationOption{
+					{
+						Key: "sku",
+						Validators: []Validator{
+							CreateValidator(IsAlphanumeric, "Invalid SKU"),
+						},
+					},
+				}), ""),
+			},
+		},
+	}
+
+	body := map[string]interface{}{
+		"address": map[string]interface{}{"street": ""},
+		"tags":    []interface{}{"a", 2},
+		"items": []interface{}{
+			map[string]interface{}{"sku": "A1"},
+			map[string]interface{}{"sku": "B-2"},
+			map[string]interface{}{},
+			"oops",
+		},
+	}
+
+	err := ValidateAll(body, options)
+	var errs ValidationErrors
+	require.ErrorAs(t, err, &errs)
+
+	expected := []ValidationError{
+		{Field: "address.street", Rule: "IsNotEmpty", Code: CodeInvalid, Value: "", Message: "value is empty"},
+		{Field: "tags[1]", Rule: "IsString", Code: CodeInvalidType, Value: 2, Message: "element at index 1: value must be a string"},
+		{Field: "items[1].sku", Rule: "IsAlphanumeric", Code: CodeInvalid, Value: "B-2", Message: "Invalid SKU"},
+		{Field: "items[2].sku", Rule: "required", Code: CodeRequired, Message: "sku is required"},
+		{Field: "items[3]", Rule: "object", Code: CodeInvalidType, Value: "oops", Message: "element at index 3 must be an object, got string"},
+	}
+	require.Len(t, errs, len(expected))
+	for i, e := range errs {
+		var ve *ValidationError
+		require.True(t, errors.As(e, &ve))
+		require.Equal(t, expected[i].Field, ve.Field)
+		require.Equal(t, expected[i].Rule, ve.Rule)
+		require.Equal(t, expected[i].Code, ve.Code)
+		require.Equal(t, expected[i].Value, ve.Value)
+		require.Equal(t, expected[i].Message, ve.Message)
+	}
+}
+
+func TestValidationErrorAs(t *testing.T) {
+	options := []ValidationOption{
+		{
+			Key: "email",
+			Validators: []Validator{
+				{Func: IsEmail, Message: "Invalid email address", Rule: "email"},
+			},
+		},
+	}
+
+	err := Validate(map[string]interface{}{"email": "invalid"}, options)
+	var ve *ValidationError
+	require.ErrorAs(t, err, &ve)
+	require.Equal(t, "email", ve.Field)
+	require.Equal(t, "email", ve.Rule)
+	require.Equal(t, "invalid", ve.Value)
+	require.EqualError(t, ve.Err, "value is not a valid email address")
+}
+
+func TestValidationErrorJSON(t *testing.T) {
+	err := Validate(map[string]interface{}{"password": "hunter2"}, Object(Field("password").MinLength(8)))
+	var ve *ValidationError
+	require.ErrorAs(t, err, &ve)
+	require.Equal(t, "hunter2", ve.Value)
+
+	data, jsonErr := json.Marshal(ve)
+	require.NoError(t, jsonErr)
+	require.JSONEq(t, `{"field":"password","rule":"MinLength","code":"invalid","key":"min_length_string","params":{"min":8},"message":"value must be at least 8 characters long"}`, string(data))
+	require.NotContains(t, string(data), "hunter2")
+}
@@ -0,0 +1,203 @@
+package validator
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Signed is the set of signed integer types.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is the set of unsigned integer types.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer is the set of integer types.
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float is the set of floating-point types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is the set of integer and floating-point types.
+type Number interface {
+	Integer | Float
+}
+
+// Rule is a validator of typed values, e.g. of struct fields, checked at compile time.
+type Rule[T any] func(value T) error
+
+// StringRule is a validator of strings.
+type StringRule = Rule[string]
+
+// NumberRule is a validator of numbers of type T.
+type NumberRule[T Number] func(value T) error
+
+// Func adapts the rule to a ValidatorFunc for map-based validation, failing on values that are not a T.
+func (r Rule[T]) Func() ValidatorFunc {
+	return func(value interface{}) error {
+		if value == nil {
+			return invalidType("Rule", "nil", value, nil)
+		}
+		v, ok := value.(T)
+		if !ok {
+			return invalidType("Rule", "type", value, Params{"type": reflect.TypeFor[T]().String()})
+		}
+		return r(v)
+	}
+}
+
+// Func adapts the rule to a ValidatorFunc for map-based validation.
+// Numbers of other types, such as the float64 numbers decoded from JSON, are converted to an integer T when they are whole
+// and in range, and rounded to the nearest value of a float T.
+func (r NumberRule[T]) Func() ValidatorFunc {
+	return func(value interface{}) error {
+		if value == nil {
+			return invalidType("NumberRule", "nil", value, nil)
+		}
+		v, ok := convertNumber[T](value)
+		if !ok {
+			return invalidType("NumberRule", "type", value, Params{"type": reflect.TypeFor[T]().String()})
+		}
+		return r(v)
+	}
+}
+
+// Rule returns the number rule as a Rule.
+func (r NumberRule[T]) Rule() Rule[T] {
+	return Rule[T](r)
+}
+
+// Check validates a typed value against rules and returns the first error.
+func Check[T any, R ~func(T) error](value T, rules ...R) error {
+	for _, rule := range rules {
+		if err := rule(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// convertNumber converts a number to T, failing on fractions or values out of range for an integer T and on values
+// out of range for a float T, which is rounded to the nearest value.
+// Conversions to floats round to the nearest value like Go conversions do.
+func convertNumber[T Number](value interface{}) (T, bool) {
+	if v, ok := value.(T); ok {
+		return v, true
+	}
+	var result T
+	n, ok := toNumber(value)
+	if !ok {
+		return result, false
+	}
+	out := reflect.ValueOf(&result).Elem()
+	switch {
+	case out.CanInt():
+		i, ok := n.int64()
+		if !ok || out.OverflowInt(i) {
+			return result, false
+		}
+		out.SetInt(i)
+	case out.CanUint():
+		u, ok := n.uint64()
+		if !ok || out.OverflowUint(u) {
+			return result, false
+		}
+		out.SetUint(u)
+	default:
+		f := n.float64()
+		if out.OverflowFloat(f) {
+			return result, false
+		}
+		out.SetFloat(f)
+	}
+	return result, true
+}
+
+// StringOf lifts a built-in validator such as IsEmail to a StringRule.
+func StringOf(fn ValidatorFunc) StringRule {
+	return func(value string) error {
+		return fn(value)
+	}
+}
+
+// StringNotEmpty checks that a string is not empty.
+func StringNotEmpty() StringRule {
+	return StringOf(IsNotEmpty)
+}
+
+// StringMinLength checks that a string is at least min bytes long.
+func StringMinLength(min int) StringRule {
+	return StringOf(MinLength(min))
+}
+
+// StringMaxLength checks that a string is at most max bytes long.
+func StringMaxLength(max int) StringRule {
+	return StringOf(MaxLength(max))
+}
+
+// StringLength checks that a string is between min and max bytes long.
+func StringLength(min, max int) StringRule {
+	return StringOf(Length(min, max))
+}
+
+// StringPattern checks that a string matches a regular expression, and panics if the pattern is invalid like Regex.
+func StringPattern(pattern string) StringRule {
+	return StringOf(Regex(pattern))
+}
+
+// StringOneOf checks that a string is one of the allowed values.
+func StringOneOf(values ...string) StringRule {
+	return func(value string) error {
+		if !slices.Contains(values, value) {
+			return invalid("StringOneOf", "one_of", value, Params{"values": values})
+		}
+		return nil
+	}
+}
+
+// NumberMin checks that a number is greater than or equal to min.
+func NumberMin[T Number](min T) NumberRule[T] {
+	return func(value T) error {
+		if value < min {
+			return invalid("NumberMin", "min", value, Params{"min": min})
+		}
+		return nil
+	}
+}
+
+// NumberMax checks that a number is less than or equal to max.
+func NumberMax[T Number](max T) NumberRule[T] {
+	return func(value T) error {
+		if value > max {
+			return invalid("NumberMax", "max", value, Params{"max": max})
+		}
+		return nil
+	}
+}
+
+// NumberBetween checks that a number is between min and max inclusive.
+func NumberBetween[T Number](min, max T) NumberRule[T] {
+	return func(value T) error {
+		if value < min || value > max {
+			return invalid("NumberBetween", "between", value, Params{"min": min, "max": max})
+		}
+		return nil
+	}
+}
+
+// NumberOneOf checks that a number is one of the allowed values.
+func NumberOneOf[T Number](values ...T) NumberRule[T] {
+	return func(value T) error {
+		if !slices.Contains(values, value) {
+			return invalid("NumberOneOf", "one_of", value, Params{"values": values})
+		}
+		return nil
+	}
+}
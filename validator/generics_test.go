@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRules(t *testing.T) {
+	t.Run("typed values", func(t *testing.T) {
+		type signup struct {
+			Username string
+			Age      uint8
+			Score    float32
+		}
+		s := signup{Username: "al", Age: 17, Score: 4.5}
+
+		require.EqualError(t, Check(s.Username, StringMinLength(3), StringPattern("^[a-z]+$")), "value must be at least 3 characters long")
+		require.EqualError(t, Check(s.Age, NumberMin[uint8](18)), "value must be greater than or equal to 18")
+		require.NoError(t, Check(s.Score, NumberBetween[float32](0, 5), NumberOneOf[float32](4.5)))
+		require.NoError(t, Check(s.Username, StringOf(IsAlpha), StringOneOf("al", "bob")))
+		require.NoError(t, Check(s.Age, NumberMax[uint8](20).Rule(), func(age uint8) error { return nil }))
+	})
+
+	t.Run("rule adapters", func(t *testing.T) {
+		positive := Rule[int](func(value int) error {
+			if value <= 0 {
+				return errors.New("value must be positive")
+			}
+			return nil
+		})
+		tests := []struct {
+			name  string
+			fn    ValidatorFunc
+			value interface{}
+			error error
+		}{
+			{"valid string", StringLength(2, 4).Func(), "abc", nil},
+			{"invalid string", StringLength(2, 4).Func(), "abcde", errors.New("value must be between 2 and 4 characters long")},
+			{"string of the wrong type", StringNotEmpty().Func(), 12, errors.New("value must be of type string")},
+			{"nil string", StringNotEmpty().Func(), nil, errors.New("value is nil")},
+			{"typed rule", positive.Func(), 0, errors.New("value must be positive")},
+			{"typed rule of the wrong type", positive.Func(), 1.0, errors.New("value must be of type int")},
+			{"whole float to int", NumberMin(18).Func(), 21.0, nil},
+			{"fractional float to int", NumberMin(18).Func(), 21.5, errors.New("value must be of type int")},
+			{"negative int to uint", NumberMin[uint](0).Func(), -1, errors.New("value must be of type uint")},
+			{"overflowing int8", NumberMax[int8](100).Func(), 300.0, errors.New("value must be of type int8")},
+			{"int to float", NumberBetween(0.5, 1.5).Func(), 1, nil},
+			{"out of range", NumberBetween(1, 5).Func(), 6.0, errors.New("value must be between 1 and 5")},
+			{"not a number", NumberMin(1).Func(), "1", errors.New("value must be of type int")},
+			{"nil number", NumberMin(1).Func(), nil, errors.New("value is nil")},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				err := test.fn(test.value)
+				if test.error == nil {
+					require.NoError(t, err)
+				} else {
+					require.EqualError(t, err, test.error.Error())
+				}
+			})
+		}
+	})
+
+	t.Run("map-based validation", func(t *testing.T) {
+		options := Object(
+			Field("username").Validate(StringMinLength(3).Func()),
+			Field("age").Validate(NumberBetween(18, 130).Func(), "{field} must be between {min} and {max}"),
+		)
+		err := ValidateAll(map[string]interface{}{"username": "al", "age": 12.0}, options)
+		require.EqualError(t, err, "value must be at least 3 characters long; age must be between 18 and 130")
+	})
+}
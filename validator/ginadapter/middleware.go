@@ -1,23 +1,55 @@
 package ginadapter
 
 import (
+	"encoding/json"
+	"errors"
+
 	"github.com/gin-gonic/gin"
 	"github.com/kthehatter/go-validator/validator"
+	"golang.org/x/text/language"
 )
 
+// Config configures MiddlewareWithConfig.
+type Config struct {
+	// UseNumber decodes JSON numbers as json.Number instead of float64, so that large integers and decimals
+	// keep their exact value through validation and validator.Decode.
+	UseNumber bool
+	// RunOptions are passed on to validator.ValidateContext.
+	RunOptions []validator.RunOption
+}
+
 // Middleware creates a Gin middleware for request validation.
-func Middleware(options []validator.ValidationOption) gin.HandlerFunc {
+// Run options such as validator.Concurrency are passed on to validator.ValidateContext.
+// Messages are translated into the locales of the Accept-Language header unless opts set validator.WithLocale.
+func Middleware(options []validator.ValidationOption, opts ...validator.RunOption) gin.HandlerFunc {
+	return MiddlewareWithConfig(options, Config{RunOptions: opts})
+}
+
+// MiddlewareWithConfig is like Middleware with the settings of config.
+func MiddlewareWithConfig(options []validator.ValidationOption, config Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var body gin.H
-		if err := c.ShouldBindJSON(&body); err != nil {
+		runOpts := config.RunOptions
+		if locales := acceptedLocales(c.GetHeader("Accept-Language")); len(locales) > 0 {
+			runOpts = append([]validator.RunOption{validator.WithLocale(locales...)}, config.RunOptions...)
+		}
+
+		body, err := bindBody(c, config.UseNumber)
+		if err != nil {
 			c.JSON(400, gin.H{"message": "Invalid request body"})
 			c.Abort()
 			return
 		}
 
-		// Run validation and return the first error
-		if err := validator.Validate(body, options); err != nil {
-			c.JSON(400, gin.H{"message": err.Error()})
+		// Run validation and return the first error along with its field details
+		if err := validator.ValidateContext(c.Request.Context(), body, options, runOpts...); err != nil {
+			errs := fieldErrors(err)
+			if errs == nil {
+				// Infrastructure failures of context validators are not the client's fault
+				c.JSON(500, gin.H{"message": "Internal server error"})
+				c.Abort()
+				return
+			}
+			c.JSON(400, gin.H{"message": err.Error(), "errors": errs})
 			c.Abort()
 			return
 		}
@@ -29,3 +61,64 @@ func Middleware(options []validator.ValidationOption) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// ValidatedBody returns the body validated by Middleware decoded into a T, see validator.Decode.
+func ValidatedBody[T any](c *gin.Context) (T, error) {
+	body, ok := c.Get("validatedBody")
+	if !ok {
+		var zero T
+		return zero, errors.New("no validated body, the route must use ginadapter.Middleware")
+	}
+	return validator.Decode[T](body.(gin.H))
+}
+
+// bindBody decodes the JSON object of the request body.
+func bindBody(c *gin.Context, useNumber bool) (gin.H, error) {
+	var body gin.H
+	if !useNumber {
+		err := c.ShouldBindJSON(&body)
+		return body, err
+	}
+	if c.Request == nil || c.Request.Body == nil {
+		return nil, errors.New("invalid request")
+	}
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// acceptedLocales returns the locales of an Accept-Language header in order of preference.
+func acceptedLocales(header string) []string {
+	if header == "" {
+		return nil
+	}
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+	locales := make([]string, len(tags))
+	for i, tag := range tags {
+		locales[i] = tag.String()
+	}
+	return locales
+}
+
+// fieldErrors flattens a validation error into the list of field failures it holds.
+// A *validator.ValidationError is kept whole even when it wraps the failures of nested values,
+// so that its path and custom message reach the client.
+func fieldErrors(err error) []*validator.ValidationError {
+	switch e := err.(type) {
+	case validator.ValidationErrors:
+		var result []*validator.ValidationError
+		for _, inner := range e {
+			result = append(result, fieldErrors(inner)...)
+		}
+		return result
+	case *validator.ValidationError:
+		return []*validator.ValidationError{e}
+	}
+	return nil
+}
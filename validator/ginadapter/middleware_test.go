@@ -0,0 +1,213 @@
+package ginadapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kthehatter/go-validator/validator"
+	"github.com/stretchr/testify/require"
+)
+
+// response is the JSON body of a rejected request.
+type response struct {
+	Message string `json:"message"`
+	Errors  []struct {
+		Field   string `json:"field"`
+		Key     string `json:"key"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// serve posts body with header to a router validating it with handler, then running next, and returns the recorded response.
+// next defaults to a handler answering 204.
+func serve(t *testing.T, handler gin.HandlerFunc, body string, header http.Header, next gin.HandlerFunc) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	if next == nil {
+		next = func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	}
+	router.POST("/", handler, next)
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+// decode decodes the JSON body of rec.
+func decode(t *testing.T, rec *httptest.ResponseRecorder) response {
+	t.Helper()
+	var res response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	return res
+}
+
+func TestMiddleware(t *testing.T) {
+	options := validator.Object(
+		validator.Field("name").Trim().MinLength(3),
+		validator.Field("age").Optional().Number().Min(18),
+	)
+
+	t.Run("valid bodies reach the handler", func(t *testing.T) {
+		next := func(c *gin.Context) {
+			c.JSON(http.StatusOK, c.MustGet("validatedBody"))
+		}
+		rec := serve(t, Middleware(options), `{"name": "  Ann  "}`, nil, next)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.JSONEq(t, `{"name": "Ann"}`, rec.Body.String())
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		rec := serve(t, Middleware(options), `{"name":`, nil, nil)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Equal(t, "Invalid request body", decode(t, rec).Message)
+	})
+
+	t.Run("validation failures", func(t *testing.T) {
+		rec := serve(t, Middleware(options), `{"name": "a"}`, nil, nil)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		res := decode(t, rec)
+		require.Equal(t, "value must be at least 3 characters long", res.Message)
+		require.Len(t, res.Errors, 1)
+		require.Equal(t, "name", res.Errors[0].Field)
+		require.Equal(t, "min_length_string", res.Errors[0].Key)
+	})
+
+	t.Run("custom messages wrapping nested failures", func(t *testing.T) {
+		items := validator.EachWithOptions(validator.Object(validator.Field("sku").MinLength(3)))
+		options := validator.Object(validator.Field("items").Validate(items, "items are invalid"))
+		rec := serve(t, Middleware(options), `{"items": [{"sku": "a"}, {"sku": "b"}]}`, nil, nil)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		res := decode(t, rec)
+		require.Equal(t, "items are invalid", res.Message)
+		require.Len(t, res.Errors, 1)
+		require.Equal(t, "items", res.Errors[0].Field)
+		require.Equal(t, "items are invalid", res.Errors[0].Message)
+	})
+
+	t.Run("every failure of ValidateAll", func(t *testing.T) {
+		rec := serve(t, Middleware(options, validator.CollectAll()), `{"name": "a", "age": 3}`, nil, nil)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		res := decode(t, rec)
+		require.Len(t, res.Errors, 2)
+		require.Equal(t, "name", res.Errors[0].Field)
+		require.Equal(t, "age", res.Errors[1].Field)
+	})
+
+	t.Run("internal errors", func(t *testing.T) {
+		called := false
+		options := validator.Object(validator.Field("name").ValidateContext(func(ctx context.Context, value interface{}) error {
+			return validator.Internal(errors.New("connection refused"))
+		}))
+		rec := serve(t, Middleware(options), `{"name": "Ann"}`, nil, func(c *gin.Context) { called = true })
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		require.Equal(t, "Internal server error", decode(t, rec).Message)
+		require.NotContains(t, rec.Body.String(), "connection refused")
+		require.False(t, called)
+	})
+
+	t.Run("locales of the Accept-Language header", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			header  string
+			opts    []validator.RunOption
+			message string
+		}{
+			{"preferred locale", "fr-CA,fr;q=0.9,en;q=0.8", nil, "la valeur doit contenir au moins 3 caractères"},
+			{"first known locale", "de, ar;q=0.5", nil, "يجب أن تحتوي القيمة على 3 أحرف على الأقل"},
+			{"unknown locales", "de", nil, "value must be at least 3 characters long"},
+			{"invalid header", ";;;", nil, "value must be at least 3 characters long"},
+			{"run options take precedence", "fr", []validator.RunOption{validator.WithLocale("en")}, "value must be at least 3 characters long"},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				header := http.Header{"Accept-Language": {test.header}}
+				rec := serve(t, Middleware(options, test.opts...), `{"name": "a"}`, header, nil)
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+				res := decode(t, rec)
+				require.Equal(t, test.message, res.Message)
+				require.Equal(t, test.message, res.Errors[0].Message)
+			})
+		}
+	})
+}
+
+func TestValidatedBody(t *testing.T) {
+	type person struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	options := validator.Object(
+		validator.Field("name").Trim().MinLength(3),
+		validator.Field("age").Number().Min(18),
+	)
+	next := func(c *gin.Context) {
+		p, err := ValidatedBody[person](c)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, p)
+	}
+
+	t.Run("decodes the transformed body", func(t *testing.T) {
+		rec := serve(t, Middleware(options), `{"name": " Ann ", "age": 30}`, nil, next)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.JSONEq(t, `{"name": "Ann", "age": 30}`, rec.Body.String())
+	})
+
+	t.Run("without the middleware", func(t *testing.T) {
+		rec := serve(t, func(c *gin.Context) { c.Next() }, `{"name": "Ann", "age": 30}`, nil, next)
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		require.Equal(t, "no validated body, the route must use ginadapter.Middleware", decode(t, rec).Message)
+	})
+}
+
+func TestUseNumber(t *testing.T) {
+	type payment struct {
+		ID     int64   `json:"id"`
+		Amount float64 `json:"amount"`
+	}
+	options := validator.Object(
+		validator.Field("id").Number().Min(1),
+		validator.Field("amount").Number().MaxDecimalPlaces(2),
+	)
+	var got payment
+	var id interface{}
+	next := func(c *gin.Context) {
+		id = c.MustGet("validatedBody").(gin.H)["id"]
+		var err error
+		got, err = ValidatedBody[payment](c)
+		require.NoError(t, err)
+		c.Status(http.StatusNoContent)
+	}
+	body := `{"id": 9007199254740993, "amount": 10.25}`
+
+	rec := serve(t, MiddlewareWithConfig(options, Config{UseNumber: true}), body, nil, next)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.Equal(t, json.Number("9007199254740993"), id)
+	require.Equal(t, payment{ID: 9007199254740993, Amount: 10.25}, got)
+
+	// Without UseNumber the id is a float64 and loses its last digit
+	rec = serve(t, MiddlewareWithConfig(options, Config{}), body, nil, next)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.Equal(t, float64(9007199254740992), id)
+
+	rec = serve(t, MiddlewareWithConfig(options, Config{UseNumber: true}), `{"id": 1, "amount": 0.125}`, nil, next)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Equal(t, "amount", decode(t, rec).Errors[0].Field)
+
+	rec = serve(t, MiddlewareWithConfig(options, Config{UseNumber: true}), `[1]`, nil, next)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Equal(t, "Invalid request body", decode(t, rec).Message)
+}
@@ -3,7 +3,6 @@ package validator
 import (
 	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -17,10 +16,38 @@ import (
 	"time"
 )
 
+// Patterns of the built-in validators, compiled once.
+var (
+	uuidRegex         = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hexColorRegex     = regexp.MustCompile(`^#([A-Fa-f0-9]{6}|[A-Fa-f0-9]{3})$`)
+	alphaRegex        = regexp.MustCompile(`^[a-zA-Z]+$`)
+	alphaNumericRegex = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+	// Arabic characters (Unicode block for Arabic and Arabic Supplement)
+	arabicRegex = regexp.MustCompile(`^[\p{Arabic}\s]+$`)
+	// Arabic and Latin alphabetic characters
+	alphaArabicRegex = regexp.MustCompile(`^[\p{Arabic}\p{Latin}\s]+$`)
+)
+
+// validImageTypes are the MIME types accepted by IsBase64Image.
+var validImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/bmp":  true,
+	"image/webp": true,
+}
+
 // IsNotEmpty checks if a value is not empty.
 func IsNotEmpty(value interface{}) error {
 	if value == nil {
-		return errors.New("value is nil")
+		return invalidType("IsNotEmpty", "nil", value, nil)
+	}
+
+	if n, ok := value.(json.Number); ok {
+		if c, ok := compareNumbers(n, 0); ok && c == 0 {
+			return invalid("IsNotEmpty", "zero", value, nil)
+		}
+		return nil
 	}
 
 	v := reflect.ValueOf(value)
@@ -28,19 +55,23 @@ func IsNotEmpty(value interface{}) error {
 	switch v.Kind() {
 	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
 		if v.Len() == 0 {
-			return errors.New("value is empty")
+			return invalid("IsNotEmpty", "empty", value, nil)
 		}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		if v.Int() == 0 {
-			return errors.New("value is zero")
+			return invalid("IsNotEmpty", "zero", value, nil)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() == 0 {
+			return invalid("IsNotEmpty", "zero", value, nil)
 		}
 	case reflect.Float32, reflect.Float64:
 		if v.Float() == 0 {
-			return errors.New("value is zero")
+			return invalid("IsNotEmpty", "zero", value, nil)
 		}
 	case reflect.Bool:
 		if !v.Bool() {
-			return errors.New("value is false")
+			return invalid("IsNotEmpty", "false", value, nil)
 		}
 	default:
 		// For unsupported types, assume the value is not empty
@@ -52,13 +83,16 @@ func IsNotEmpty(value interface{}) error {
 
 // IsAlphanumeric checks if a string contains only alphanumeric characters.
 func IsAlphanumeric(value interface{}) error {
+	if value == nil {
+		return invalidType("IsAlphanumeric", "nil", value, nil)
+	}
 	str, ok := value.(string)
 	if !ok {
-		return errors.New("value is not a string")
+		return invalidType("IsAlphanumeric", "not_string", value, nil)
 	}
 	for _, char := range str {
 		if !((char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || (char >= '0' && char <= '9')) {
-			return errors.New("value contains invalid characters")
+			return invalid("IsAlphanumeric", "alphanumeric_chars", value, nil)
 		}
 	}
 	return nil
@@ -66,16 +100,19 @@ func IsAlphanumeric(value interface{}) error {
 
 // IsEmail checks if a string is a valid email address.
 func IsEmail(value interface{}) error {
+	if value == nil {
+		return invalidType("IsEmail", "nil", value, nil)
+	}
 	// Check if the input is a string
 	str, ok := value.(string)
 	if !ok {
-		return errors.New("value is not a string")
+		return invalidType("IsEmail", "not_string", value, nil)
 	}
 
 	// Use net/mail to validate the email
 	_, err := mail.ParseAddress(str)
 	if err != nil {
-		return errors.New("value is not a valid email address")
+		return invalid("IsEmail", "email", value, nil)
 	}
 
 	return nil
@@ -86,14 +123,14 @@ func IsIn(allowedValues ...interface{}) ValidatorFunc {
 	return func(value interface{}) error {
 		// check if the value is nil
 		if value == nil {
-			return errors.New("value is nil")
+			return invalidType("IsIn", "nil", value, nil)
 		}
 		for _, allowed := range allowedValues {
 			if reflect.DeepEqual(value, allowed) {
 				return nil
 			}
 		}
-		return fmt.Errorf("value must be one of %v", allowedValues)
+		return invalid("IsIn", "one_of", value, Params{"values": allowedValues})
 	}
 }
 
@@ -102,11 +139,11 @@ func IsNotIn(disallowedValues ...interface{}) ValidatorFunc {
 	return func(value interface{}) error {
 		// check if the value is nil
 		if value == nil {
-			return errors.New("value is nil")
+			return invalidType("IsNotIn", "nil", value, nil)
 		}
 		for _, disallowed := range disallowedValues {
 			if reflect.DeepEqual(value, disallowed) {
-				return fmt.Errorf("value must not be one of %v", disallowedValues)
+				return invalid("IsNotIn", "not_one_of", value, Params{"values": disallowedValues})
 			}
 		}
 		return nil
@@ -118,18 +155,18 @@ func IsInArray(array interface{}) ValidatorFunc {
 	return func(value interface{}) error {
 		// check if the value is nil
 		if value == nil {
-			return errors.New("value is nil")
+			return invalidType("IsInArray", "nil", value, nil)
 		}
 		arr := reflect.ValueOf(array)
 		if arr.Kind() != reflect.Slice && arr.Kind() != reflect.Array {
-			return fmt.Errorf("expected an array or slice, got %T", array)
+			return invalidType("IsInArray", "array_param", value, Params{"type": fmt.Sprintf("%T", array)})
 		}
 		for i := 0; i < arr.Len(); i++ {
 			if reflect.DeepEqual(value, arr.Index(i).Interface()) {
 				return nil
 			}
 		}
-		return fmt.Errorf("value must be one of %v", array)
+		return invalid("IsInArray", "one_of", value, Params{"values": array})
 	}
 }
 
@@ -138,15 +175,15 @@ func IsNotInArray(array interface{}) ValidatorFunc {
 	return func(value interface{}) error {
 		// check if the value is nil
 		if value == nil {
-			return errors.New("value is nil")
+			return invalidType("IsNotInArray", "nil", value, nil)
 		}
 		arr := reflect.ValueOf(array)
 		if arr.Kind() != reflect.Slice && arr.Kind() != reflect.Array {
-			return fmt.Errorf("expected an array or slice, got %T", array)
+			return invalidType("IsNotInArray", "array_param", value, Params{"type": fmt.Sprintf("%T", array)})
 		}
 		for i := 0; i < arr.Len(); i++ {
 			if reflect.DeepEqual(value, arr.Index(i).Interface()) {
-				return fmt.Errorf("value must not be one of %v", array)
+				return invalid("IsNotInArray", "not_one_of", value, Params{"values": array})
 			}
 		}
 		return nil
@@ -155,128 +192,152 @@ func IsNotInArray(array interface{}) ValidatorFunc {
 
 // IsString checks if a value is a string.
 func IsString(value interface{}) error {
+	if value == nil {
+		return invalidType("IsString", "nil", value, nil)
+	}
 	if reflect.TypeOf(value).Kind() != reflect.String {
-		return errors.New("value must be a string")
+		return invalidType("IsString", "string", value, nil)
 	}
 	return nil
 }
 
-// IsNumber checks if a value is a number (int or float).
+// IsNumber checks if a value is a number: an integer, a float, a json.Number or a *big.Int, *big.Float or *big.Rat.
 func IsNumber(value interface{}) error {
-	v := reflect.ValueOf(value)
-	switch v.Kind() {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-		reflect.Float32, reflect.Float64:
-		return nil
-	default:
-		return errors.New("value must be a number")
+	if value == nil {
+		return invalidType("IsNumber", "nil", value, nil)
+	}
+	if _, ok := toNumber(value); !ok {
+		return invalidType("IsNumber", "number", value, nil)
 	}
+	return nil
 }
 
-// IsInt checks if a value is an integer.
+// IsInt checks if a value is an integer: a signed or unsigned int, a *big.Int or a json.Number without fraction or exponent.
 func IsInt(value interface{}) error {
-	v := reflect.ValueOf(value)
-	switch v.Kind() {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return nil
-	default:
-		return errors.New("value must be an integer")
+	if value == nil {
+		return invalidType("IsInt", "nil", value, nil)
+	}
+	if !isInteger(value) {
+		return invalidType("IsInt", "integer", value, nil)
 	}
+	return nil
 }
 
-// IsFloat checks if a value is a float.
+// IsFloat checks if a value is a float, a *big.Float or a json.Number with a fraction or exponent.
 func IsFloat(value interface{}) error {
-	v := reflect.ValueOf(value)
-	switch v.Kind() {
-	case reflect.Float32, reflect.Float64:
-		return nil
-	default:
-		return errors.New("value must be a float")
+	if value == nil {
+		return invalidType("IsFloat", "nil", value, nil)
 	}
+	if !isFloat(value) {
+		return invalidType("IsFloat", "float", value, nil)
+	}
+	return nil
 }
 
 // IsBool checks if a value is a boolean.
 func IsBool(value interface{}) error {
+	if value == nil {
+		return invalidType("IsBool", "nil", value, nil)
+	}
 	if reflect.TypeOf(value).Kind() != reflect.Bool {
-		return errors.New("value must be a boolean")
+		return invalidType("IsBool", "boolean", value, nil)
 	}
 	return nil
 }
 
 // IsSlice checks if a value is a slice.
 func IsSlice(value interface{}) error {
+	if value == nil {
+		return invalidType("IsSlice", "nil", value, nil)
+	}
 	if reflect.TypeOf(value).Kind() != reflect.Slice {
-		return errors.New("value must be a slice")
+		return invalidType("IsSlice", "slice", value, nil)
 	}
 	return nil
 }
 
 // IsMap checks if a value is a map.
 func IsMap(value interface{}) error {
+	if value == nil {
+		return invalidType("IsMap", "nil", value, nil)
+	}
 	if reflect.TypeOf(value).Kind() != reflect.Map {
-		return errors.New("value must be a map")
+		return invalidType("IsMap", "map", value, nil)
 	}
 	return nil
 }
 
 // IsURL checks if a string is a valid URL.
 func IsURL(value interface{}) error {
+	if value == nil {
+		return invalidType("IsURL", "nil", value, nil)
+	}
 	str, ok := value.(string)
 	if !ok {
-		return errors.New("value must be a string")
+		return invalidType("IsURL", "string", value, nil)
 	}
 	_, err := url.ParseRequestURI(str)
 	if err != nil {
-		return errors.New("value is not a valid URL")
+		return invalid("IsURL", "url", value, nil)
 	}
 	return nil
 }
 
 // IsUUID checks if a string is a valid UUID.
 func IsUUID(value interface{}) error {
+	if value == nil {
+		return invalidType("IsUUID", "nil", value, nil)
+	}
 	str, ok := value.(string)
 	if !ok {
-		return errors.New("value must be a string")
+		return invalidType("IsUUID", "string", value, nil)
 	}
-	uuidRegex := `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`
-	matched, err := regexp.MatchString(uuidRegex, str)
-	if err != nil || !matched {
-		return errors.New("value is not a valid UUID")
+	if !uuidRegex.MatchString(str) {
+		return invalid("IsUUID", "uuid", value, nil)
 	}
 	return nil
 }
 
 // IsDate checks if a string is a valid date in the format YYYY-MM-DD.
 func IsDate(value interface{}) error {
+	if value == nil {
+		return invalidType("IsDate", "nil", value, nil)
+	}
 	str, ok := value.(string)
 	if !ok {
-		return errors.New("value must be a string")
+		return invalidType("IsDate", "string", value, nil)
 	}
 	_, err := time.Parse("2006-01-02", str)
 	if err != nil {
-		return errors.New("value is not a valid date (expected format: YYYY-MM-DD)")
+		return invalid("IsDate", "date", value, nil)
 	}
 	return nil
 }
 
 // IsTime checks if a string is a valid time in the format HH:MM:SS.
 func IsTime(value interface{}) error {
+	if value == nil {
+		return invalidType("IsTime", "nil", value, nil)
+	}
 	str, ok := value.(string)
 	if !ok {
-		return errors.New("value must be a string")
+		return invalidType("IsTime", "string", value, nil)
 	}
 	_, err := time.Parse("15:04:05", str)
 	if err != nil {
-		return errors.New("value is not a valid time (expected format: HH:MM:SS)")
+		return invalid("IsTime", "time", value, nil)
 	}
 	return nil
 }
 
 // IsCreditCard checks if a string is a valid credit card number using the Luhn algorithm.
 func IsCreditCard(value interface{}) error {
+	if value == nil {
+		return invalidType("IsCreditCard", "nil", value, nil)
+	}
 	str, ok := value.(string)
 	if !ok {
-		return errors.New("value must be a string")
+		return invalidType("IsCreditCard", "string", value, nil)
 	}
 	// Remove spaces and dashes
 	str = strings.ReplaceAll(str, " ", "")
@@ -284,7 +345,7 @@ func IsCreditCard(value interface{}) error {
 
 	// Check if the string is a valid number
 	if _, err := strconv.Atoi(str); err != nil {
-		return errors.New("value is not a valid credit card number")
+		return invalid("IsCreditCard", "credit_card", value, nil)
 	}
 
 	// Luhn algorithm
@@ -303,148 +364,160 @@ func IsCreditCard(value interface{}) error {
 	}
 
 	if sum%10 != 0 {
-		return errors.New("value is not a valid credit card number")
+		return invalid("IsCreditCard", "credit_card", value, nil)
 	}
 	return nil
 }
 
 // IsHexColor checks if a string is a valid hexadecimal color code.
 func IsHexColor(value interface{}) error {
+	if value == nil {
+		return invalidType("IsHexColor", "nil", value, nil)
+	}
 	str, ok := value.(string)
 	if !ok {
-		return errors.New("value must be a string")
+		return invalidType("IsHexColor", "string", value, nil)
 	}
-	hexColorRegex := `^#([A-Fa-f0-9]{6}|[A-Fa-f0-9]{3})$`
-	matched, err := regexp.MatchString(hexColorRegex, str)
-	if err != nil || !matched {
-		return errors.New("value is not a valid hexadecimal color code")
+	if !hexColorRegex.MatchString(str) {
+		return invalid("IsHexColor", "hex_color", value, nil)
 	}
 	return nil
 }
 
 // IsJSON checks if a string is valid JSON.
 func IsJSON(value interface{}) error {
+	if value == nil {
+		return invalidType("IsJSON", "nil", value, nil)
+	}
 	str, ok := value.(string)
 	if !ok {
-		return errors.New("value must be a string")
+		return invalidType("IsJSON", "string", value, nil)
 	}
 	var js json.RawMessage
 	if err := json.Unmarshal([]byte(str), &js); err != nil {
-		return errors.New("value is not valid JSON")
+		return invalid("IsJSON", "json", value, nil)
 	}
 	return nil
 }
 
 // IsIP checks if a string is a valid IP address (IPv4 or IPv6).
 func IsIP(value interface{}) error {
+	if value == nil {
+		return invalidType("IsIP", "nil", value, nil)
+	}
 	str, ok := value.(string)
 	if !ok {
-		return errors.New("value must be a string")
+		return invalidType("IsIP", "string", value, nil)
 	}
 	if net.ParseIP(str) == nil {
-		return errors.New("value is not a valid IP address")
+		return invalid("IsIP", "ip", value, nil)
 	}
 	return nil
 }
 
 // IsAlpha checks if a string contains only alphabetic characters.
 func IsAlpha(value interface{}) error {
+	if value == nil {
+		return invalidType("IsAlpha", "nil", value, nil)
+	}
 	str, ok := value.(string)
 	if !ok {
-		return errors.New("value must be a string")
+		return invalidType("IsAlpha", "string", value, nil)
 	}
-	alphaRegex := `^[a-zA-Z]+$`
-	matched, err := regexp.MatchString(alphaRegex, str)
-	if err != nil || !matched {
-		return errors.New("value must contain only alphabetic characters")
+	if !alphaRegex.MatchString(str) {
+		return invalid("IsAlpha", "alpha", value, nil)
 	}
 	return nil
 }
 
 // IsAlphaNumeric checks if a string contains only alphanumeric characters.
 func IsAlphaNumeric(value interface{}) error {
+	if value == nil {
+		return invalidType("IsAlphaNumeric", "nil", value, nil)
+	}
 	str, ok := value.(string)
 	if !ok {
-		return errors.New("value must be a string")
+		return invalidType("IsAlphaNumeric", "string", value, nil)
 	}
-	alphaNumericRegex := `^[a-zA-Z0-9]+$`
-	matched, err := regexp.MatchString(alphaNumericRegex, str)
-	if err != nil || !matched {
-		return errors.New("value must contain only alphanumeric characters")
+	if !alphaNumericRegex.MatchString(str) {
+		return invalid("IsAlphaNumeric", "alphanumeric", value, nil)
 	}
 	return nil
 }
 
 // IsArabic checks if a string contains only Arabic characters (including spaces and common Arabic punctuation).
 func IsArabic(value interface{}) error {
+	if value == nil {
+		return invalidType("IsArabic", "nil", value, nil)
+	}
 	str, ok := value.(string)
 	if !ok {
-		return errors.New("value must be a string")
+		return invalidType("IsArabic", "string", value, nil)
 	}
 
-	// Regex to match Arabic characters (Unicode block for Arabic and Arabic Supplement)
-	arabicRegex := `^[\p{Arabic}\s]+$`
-	matched, err := regexp.MatchString(arabicRegex, str)
-	if err != nil {
-		return errors.New("an error occurred while validating the string")
-	}
-	if !matched {
-		return errors.New("value must contain only Arabic characters")
+	if !arabicRegex.MatchString(str) {
+		return invalid("IsArabic", "arabic", value, nil)
 	}
 	return nil
 }
 
 // IsAlphaArabic checks if a string contains only Arabic and Latin alphabetic characters.
 func IsAlphaArabic(value interface{}) error {
+	if value == nil {
+		return invalidType("IsAlphaArabic", "nil", value, nil)
+	}
 	str, ok := value.(string)
 	if !ok {
-		return errors.New("value must be a string")
+		return invalidType("IsAlphaArabic", "string", value, nil)
 	}
-	// Regex to match Arabic and Latin alphabetic characters
-	alphaArabicRegex := `^[\p{Arabic}\p{Latin}\s]+$`
-	matched, err := regexp.MatchString(alphaArabicRegex, str)
-	if err != nil || !matched {
-		return errors.New("value must contain only Arabic and Latin alphabetic characters")
+	if !alphaArabicRegex.MatchString(str) {
+		return invalid("IsAlphaArabic", "alpha_arabic", value, nil)
 	}
 	return nil
 }
 
 // IsBase64 checks if a string is valid Base64-encoded data.
 func IsBase64(value interface{}) error {
+	if value == nil {
+		return invalidType("IsBase64", "nil", value, nil)
+	}
 	str, ok := value.(string)
 	if !ok {
-		return errors.New("value must be a string")
+		return invalidType("IsBase64", "string", value, nil)
 	}
 	_, err := base64.StdEncoding.DecodeString(str)
 	if err != nil {
-		return errors.New("value is not valid Base64")
+		return invalid("IsBase64", "base64", value, nil)
 	}
 	return nil
 }
 
 // IsBase64Image checks if a string is valid Base64-encoded image data.
 func IsBase64Image(value interface{}) error {
+	if value == nil {
+		return invalidType("IsBase64Image", "nil", value, nil)
+	}
 	// Ensure the input is a string
 	str, ok := value.(string)
 	if !ok {
-		return errors.New("value must be a string")
+		return invalidType("IsBase64Image", "string", value, nil)
 	}
 
 	// Check if the string is a valid base64-encoded image
 	if !strings.HasPrefix(str, "data:image/") {
-		return errors.New("invalid base64 image format: must start with 'data:image/'")
+		return invalid("IsBase64Image", "base64_image_prefix", value, nil)
 	}
 
 	// Extract the base64 data (remove the prefix)
 	base64Data := strings.SplitN(str, ",", 2)
 	if len(base64Data) != 2 {
-		return errors.New("invalid base64 image format: missing data prefix")
+		return invalid("IsBase64Image", "base64_image_data", value, nil)
 	}
 
 	// Decode the base64 string
 	decodedData, err := base64.StdEncoding.DecodeString(base64Data[1])
 	if err != nil {
-		return errors.New("value is not valid Base64 image")
+		return invalid("IsBase64Image", "base64_image", value, nil)
 	}
 
 	// Debug: Print the decoded data length
@@ -454,16 +527,8 @@ func IsBase64Image(value interface{}) error {
 	mimeType := http.DetectContentType(decodedData)
 
 	// Check if the MIME type is a valid image type
-	validImageTypes := map[string]bool{
-		"image/jpeg": true,
-		"image/png":  true,
-		"image/gif":  true,
-		"image/bmp":  true,
-		"image/webp": true,
-	}
-
 	if validImageTypes[mimeType] {
 		return nil
 	}
-	return errors.New("invalid image format")
+	return invalid("IsBase64Image", "image_format", value, nil)
 }
@@ -1,7 +1,10 @@
 package validator
 
 import (
+	"encoding/json"
 	"errors"
+	"math"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,6 +27,9 @@ func TestIsNotEmpty(t *testing.T) {
 		{name: "Zero int", value: 0, expected: errors.New("value is zero")},
 		{name: "Non-zero float", value: 3.14, expected: nil},
 		{name: "Zero float", value: 0.0, expected: errors.New("value is zero")},
+		{name: "Zero uint", value: uint(0), expected: errors.New("value is zero")},
+		{name: "Zero json.Number", value: json.Number("0.00"), expected: errors.New("value is zero")},
+		{name: "Non-zero json.Number", value: json.Number("0.01"), expected: nil},
 
 		// Booleans
 		{name: "True boolean", value: true, expected: nil},
@@ -68,7 +74,11 @@ func TestIsAlphanumeric(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsAlphanumeric(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -88,7 +98,11 @@ func TestIsEmail(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsEmail(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -109,7 +123,11 @@ func TestIsIn(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := isIn(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -128,7 +146,11 @@ func TestIsNotIn(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := isNotIn(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -148,7 +170,11 @@ func TestIsInArray(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := isInArray(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -168,7 +194,11 @@ func TestIsNotInArray(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := isNotInArray(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -186,7 +216,11 @@ func TestIsString(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsString(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -200,12 +234,20 @@ func TestIsNumber(t *testing.T) {
 		{"valid integer", 123, nil},
 		{"valid float", 123.45, nil},
 		{"invalid type (string)", "123", errors.New("value must be a number")},
+		{"valid unsigned integer", uint64(math.MaxUint64), nil},
+		{"valid json.Number", json.Number("1e400"), nil},
+		{"valid big.Int", big.NewInt(1), nil},
+		{"invalid json.Number", json.Number("12abc"), errors.New("value must be a number")},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsNumber(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -219,12 +261,20 @@ func TestIsInt(t *testing.T) {
 		{"valid integer", 123, nil},
 		{"invalid type (float)", 123.45, errors.New("value must be an integer")},
 		{"invalid type (string)", "123", errors.New("value must be an integer")},
+		{"valid unsigned integer", uint8(1), nil},
+		{"valid json.Number", json.Number("123456789012345678901234567890"), nil},
+		{"valid big.Int", new(big.Int).Lsh(big.NewInt(1), 100), nil},
+		{"invalid json.Number (decimal)", json.Number("1.0"), errors.New("value must be an integer")},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsInt(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -238,12 +288,20 @@ func TestIsFloat(t *testing.T) {
 		{"valid float", 123.45, nil},
 		{"invalid type (int)", 123, errors.New("value must be a float")},
 		{"invalid type (string)", "123.45", errors.New("value must be a float")},
+		{"valid json.Number", json.Number("1.5e3"), nil},
+		{"valid big.Float", big.NewFloat(1.5), nil},
+		{"invalid json.Number (integer)", json.Number("15"), errors.New("value must be a float")},
+		{"invalid type (uint)", uint(1), errors.New("value must be a float")},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsFloat(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -263,7 +321,11 @@ func TestIsBool(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsBool(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -282,7 +344,11 @@ func TestIsSlice(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsSlice(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -301,7 +367,11 @@ func TestIsMap(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsMap(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -320,7 +390,11 @@ func TestIsURL(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsURL(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -339,7 +413,11 @@ func TestIsUUID(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsUUID(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -358,7 +436,11 @@ func TestIsDate(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsDate(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -377,7 +459,11 @@ func TestIsTime(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsTime(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -396,7 +482,11 @@ func TestIsCreditCard(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsCreditCard(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -416,7 +506,11 @@ func TestIsHexColor(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsHexColor(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -435,7 +529,11 @@ func TestIsJSON(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsJSON(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -455,7 +553,11 @@ func TestIsIP(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsIP(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -474,7 +576,11 @@ func TestIsAlpha(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsAlpha(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -493,7 +599,11 @@ func TestIsAlphaNumeric(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsAlphaNumeric(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -512,7 +622,11 @@ func TestIsBase64(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsBase64(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -531,7 +645,11 @@ func TestIsArabic(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsArabic(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -550,7 +668,11 @@ func TestIsAlphaArabic(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsAlphaArabic(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -590,7 +712,35 @@ func TestIsBase64Image(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := IsBase64Image(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
+		})
+	}
+}
+
+func TestNilValues(t *testing.T) {
+	validators := map[string]ValidatorFunc{
+		"IsNotEmpty": IsNotEmpty, "IsAlphanumeric": IsAlphanumeric, "IsEmail": IsEmail,
+		"IsIn": IsIn("a"), "IsNotIn": IsNotIn("a"), "IsInArray": IsInArray([]string{"a"}), "IsNotInArray": IsNotInArray([]string{"a"}),
+		"IsString": IsString, "IsNumber": IsNumber, "IsInt": IsInt, "IsFloat": IsFloat, "IsBool": IsBool,
+		"IsSlice": IsSlice, "IsMap": IsMap, "IsURL": IsURL, "IsUUID": IsUUID, "IsDate": IsDate, "IsTime": IsTime,
+		"IsCreditCard": IsCreditCard, "IsHexColor": IsHexColor, "IsJSON": IsJSON, "IsIP": IsIP, "IsAlpha": IsAlpha,
+		"IsAlphaNumeric": IsAlphaNumeric, "IsArabic": IsArabic, "IsAlphaArabic": IsAlphaArabic,
+		"IsBase64": IsBase64, "IsBase64Image": IsBase64Image,
+		"MinLength": MinLength(1), "MaxLength": MaxLength(1), "Length": Length(1, 2), "Min": Min(1), "Max": Max(1),
+		"Regex": Regex("^a$"),
+	}
+	for name, fn := range validators {
+		t.Run(name, func(t *testing.T) {
+			err := fn(nil)
+			require.EqualError(t, err, "value is nil")
+			var e *ValidationError
+			require.ErrorAs(t, err, &e)
+			require.Equal(t, CodeInvalidType, e.Code)
+			require.Equal(t, name, e.Rule)
 		})
 	}
 }
@@ -0,0 +1,154 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"sort"
+)
+
+// MapOf validates an object with arbitrary keys, such as {"en": "...", "fr": "..."}: every key against keys,
+// e.g. Regex or IsIn, then the value of every valid key against value, whose key is ignored, e.g. Field("").String()
+// or Field("").Object(...). value may be nil to only check keys. Failures are reported at the path of their key.
+// Like EachWithOptions, every failing key is reported, so ValidateAll can list them all while Validate keeps the first one.
+func MapOf(keys []ValidatorFunc, value FieldSpec) ValidatorFunc {
+	fn := MapOfContext(keys, value)
+	return func(v interface{}) error {
+		return fn(context.WithValue(context.Background(), collectAllKey{}, true), v)
+	}
+}
+
+// MapOfContext is like MapOf but passes the context of the run to context validators of the values.
+// It follows the mode of the run, stopping at the first failing key in sorted order unless the run collects every failure.
+func MapOfContext(keys []ValidatorFunc, value FieldSpec) ContextValidatorFunc {
+	var option ValidationOption
+	if value != nil {
+		option = value.Option()
+	}
+	return func(ctx context.Context, v interface{}) error {
+		if v == nil {
+			return invalidType("MapOf", "nil", v, nil)
+		}
+		object, ok := toObject(v)
+		if !ok {
+			return invalidType("MapOf", "map", v, nil)
+		}
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		collectAll := collectsAll(ctx)
+		var opts []RunOption
+		if collectAll {
+			opts = []RunOption{CollectAll()}
+		}
+		var errs ValidationErrors
+		options := make([]ValidationOption, len(names))
+		for i, name := range names {
+			options[i] = ValidationOption{Key: name, IsOptional: true}
+			if len(errs) > 0 && !collectAll {
+				// Keys after the first failing one are only declared
+				continue
+			}
+			if err := checkKey(name, keys); err != nil {
+				if isInternal(err) {
+					return err
+				}
+				errs = append(errs, err)
+				continue
+			}
+			if value != nil {
+				options[i] = option
+				options[i].Key = name
+			}
+		}
+		// Keys that failed are still declared, so that they are not reported again as unknown keys
+		if err := ValidateContext(ctx, object, options, opts...); err != nil {
+			nested, ok := err.(ValidationErrors)
+			if !ok {
+				// In first-error mode, the failure of a value precedes the failing key if any
+				return err
+			}
+			errs = append(errs, nested...)
+		}
+		if len(errs) == 1 {
+			return errs[0]
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+		return nil
+	}
+}
+
+// checkKey validates a key of a MapOf object, reporting the first failure at the path of the key.
+func checkKey(name string, keys []ValidatorFunc) error {
+	for _, fn := range keys {
+		err := fn(name)
+		if err == nil {
+			continue
+		}
+		if isInternal(err) {
+			return err
+		}
+		e := invalid(ruleName(fn), "map_key", name, Params{"key": name, "error": err.Error()})
+		e.Field = name
+		e.Err = err
+		if inner, ok := err.(*ValidationError); ok {
+			e.Code = inner.Code
+		}
+		return e
+	}
+	return nil
+}
+
+// toObject returns a map with string keys as a map[string]interface{}, copying maps of other types.
+func toObject(value interface{}) (map[string]interface{}, bool) {
+	if object, ok := value.(map[string]interface{}); ok {
+		return object, true
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	object := make(map[string]interface{}, v.Len())
+	for iter := v.MapRange(); iter.Next(); {
+		object[iter.Key().String()] = iter.Value().Interface()
+	}
+	return object, true
+}
+
+// MinProperties checks if a map has at least min keys.
+func MinProperties(min int) ValidatorFunc {
+	return func(value interface{}) error {
+		if value == nil {
+			return invalidType("MinProperties", "nil", value, nil)
+		}
+		v := reflect.ValueOf(value)
+		if v.Kind() != reflect.Map {
+			return invalidType("MinProperties", "map", value, nil)
+		}
+		if v.Len() < min {
+			return invalid("MinProperties", "min_properties", value, Params{"min": min})
+		}
+		return nil
+	}
+}
+
+// MaxProperties checks if a map has at most max keys.
+func MaxProperties(max int) ValidatorFunc {
+	return func(value interface{}) error {
+		if value == nil {
+			return invalidType("MaxProperties", "nil", value, nil)
+		}
+		v := reflect.ValueOf(value)
+		if v.Kind() != reflect.Map {
+			return invalidType("MaxProperties", "map", value, nil)
+		}
+		if v.Len() > max {
+			return invalid("MaxProperties", "max_properties", value, Params{"max": max})
+		}
+		return nil
+	}
+}
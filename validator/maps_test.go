@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapOf(t *testing.T) {
+	translations := MapOf([]ValidatorFunc{Regex(`^[a-z]{2}$`), IsIn("en", "fr", "ar")}, Field("").Trim().String().MinLength(2))
+
+	tests := []struct {
+		name  string
+		input interface{}
+		error string
+	}{
+		{"valid", map[string]interface{}{"en": "Hello", "fr": "Bonjour"}, ""},
+		{"empty", map[string]interface{}{}, ""},
+		{"typed map", map[string]string{"ar": "مرحبا"}, ""},
+		{"invalid key", map[string]interface{}{"EN": "Hello"}, "key EN: value does not match the required pattern"},
+		{"key not allowed", map[string]interface{}{"de": "Hallo", "en": "Hello"}, "key de: value must be one of [en fr ar]"},
+		{"invalid value", map[string]interface{}{"en": " H ", "fr": 1}, "value must be at least 2 characters long; value must be a string"},
+		{"null value", map[string]interface{}{"en": nil}, "en must not be null"},
+		{"not a map", []interface{}{"en"}, "value must be a map"},
+		{"nil", nil, "value is nil"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := translations(test.input)
+			if test.error == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error)
+			}
+		})
+	}
+
+	t.Run("error paths and transformed values", func(t *testing.T) {
+		options := Object(
+			Field("translations").MapOf([]ValidatorFunc{IsIn("en", "fr")}, Field("").Trim().MinLength(2)).MaxProperties(2),
+			Field("metadata").Optional().MinProperties(1).MapOf(nil, Field("").Object(Field("value").Int())),
+		)
+		body := map[string]interface{}{
+			"translations": map[string]interface{}{"en": " Hello ", "fr": "B", "it": "Ciao"},
+			"metadata":     map[string]interface{}{"size": map[string]interface{}{"value": 3}, "color": map[string]interface{}{}},
+		}
+		err := ValidateAll(body, options)
+		require.EqualError(t, err, "key it: value must be one of [en fr]; value must be at least 2 characters long; value is required")
+
+		var errs ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		fields := make([]string, len(errs))
+		for i, e := range errs {
+			fields[i] = e.(*ValidationError).Field
+		}
+		require.Equal(t, []string{"translations.it", "translations.fr", "metadata.color.value"}, fields)
+		require.Equal(t, "Hello", body["translations"].(map[string]interface{})["en"])
+	})
+
+	t.Run("follows the mode of the run", func(t *testing.T) {
+		checked := 0
+		value := Field("").ValidateContext(func(ctx context.Context, value interface{}) error {
+			checked++
+			return nil
+		}).MinLength(2)
+		options := Object(Field("translations").MapOf([]ValidatorFunc{IsIn("en", "fr", "it")}, value))
+		tests := []struct {
+			name  string
+			input map[string]interface{}
+			field string
+		}{
+			{"first key", map[string]interface{}{"ar": "x", "de": "y", "en": "z", "fr": "Bonjour"}, "translations.ar"},
+			{"later key", map[string]interface{}{"de": "Hallo", "en": "x", "fr": "Bonjour"}, "translations.de"},
+			{"value before a key", map[string]interface{}{"en": "x", "fr": "Bonjour", "zz": "Hallo"}, "translations.en"},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				err := Validate(map[string]interface{}{"translations": test.input}, options)
+				var ve *ValidationError
+				require.ErrorAs(t, err, &ve)
+				require.Equal(t, test.field, ve.Field)
+			})
+		}
+
+		checked = 0
+		require.Error(t, Validate(map[string]interface{}{"translations": tests[0].input}, options))
+		require.Equal(t, 0, checked)
+
+		err := ValidateAll(map[string]interface{}{"translations": tests[0].input}, options)
+		var errs ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 3)
+	})
+
+	t.Run("properties", func(t *testing.T) {
+		require.EqualError(t, MaxProperties(1)(map[string]int{"a": 1, "b": 2}), "value must have at most 1 properties")
+		require.EqualError(t, MinProperties(1)(map[string]interface{}{}), "value must have at least 1 properties")
+		require.EqualError(t, MinProperties(1)("a"), "value must be a map")
+	})
+
+	t.Run("strict nested values and localized keys", func(t *testing.T) {
+		options := Object(Field("labels").Strict().MapOf([]ValidatorFunc{IsIn("en")}, Field("").Object(Field("text").String())))
+		body := map[string]interface{}{"labels": map[string]interface{}{
+			"en": map[string]interface{}{"text": "Hi", "extra": true},
+			"xx": "?",
+		}}
+		err := ValidateContext(context.Background(), body, options, CollectAll(), WithLocale("fr"))
+		require.EqualError(t, err, "clé xx : la valeur doit être l'une des suivantes : [en]; extra n'est pas autorisé")
+	})
+}
@@ -0,0 +1,455 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Translator renders the message identified by key in a locale, reporting whether it knows the message.
+type Translator interface {
+	Translate(locale, key string, params Params) (string, bool)
+}
+
+// Catalog is a Translator holding message templates per locale and key, e.g. Catalog{"fr": {"email": "..."}}.
+// Templates reference parameters with placeholders such as {min}.
+type Catalog map[string]map[string]string
+
+// Translate renders the template of key in locale, falling back from a regional locale such as "fr-CA" to "fr".
+func (c Catalog) Translate(locale, key string, params Params) (string, bool) {
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	for {
+		if template, ok := c[locale][key]; ok {
+			return render(template, params), true
+		}
+		i := strings.LastIndex(locale, "-")
+		if i < 0 {
+			return "", false
+		}
+		locale = locale[:i]
+	}
+}
+
+// WithLocale makes ValidateContext translate the messages of built-in validators into the first of the given locales
+// the translator knows, e.g. WithLocale("fr-CA", "en"). Custom Validator messages are left as is.
+func WithLocale(locales ...string) RunOption {
+	return func(v *validation) {
+		v.locales = locales
+	}
+}
+
+// WithTranslator sets the Translator used by WithLocale, DefaultCatalog by default.
+func WithTranslator(translator Translator) RunOption {
+	return func(v *validation) {
+		v.translator = translator
+	}
+}
+
+// render replaces the {name} placeholders of a template with the matching parameters.
+// Unknown placeholders are kept as is, and "{{" and "}}" escape literal braces.
+func render(template string, params Params) string {
+	if !strings.ContainsAny(template, "{}") {
+		return template
+	}
+	var b strings.Builder
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		if (c == '{' || c == '}') && i+1 < len(template) && template[i+1] == c {
+			b.WriteByte(c)
+			i++
+			continue
+		}
+		if c == '{' {
+			if end := strings.IndexAny(template[i+1:], "{}"); end >= 0 && template[i+1+end] == '}' {
+				name := template[i+1 : i+1+end]
+				if param, ok := params[name]; ok {
+					b.WriteString(fmt.Sprint(param))
+					i += end + 1
+					continue
+				}
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// messageParams returns the parameters available to a custom Validator message: the rule's own parameters,
+// plus {field}, {path}, {value}, {len} when the value has a length, and {error} holding the validator's message.
+func messageParams(path string, value interface{}, err error) Params {
+	params := Params{}
+	if e, ok := err.(*ValidationError); ok {
+		for name, param := range e.Params {
+			params[name] = param
+		}
+	}
+	params["field"] = fieldName(path)
+	params["path"] = path
+	params["value"] = value
+	params["error"] = err.Error()
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		params["len"] = v.Len()
+	}
+	return params
+}
+
+// fieldName returns the last field name of a path, e.g. "sku" for "items[3].sku".
+func fieldName(path string) string {
+	for strings.HasSuffix(path, "]") {
+		i := strings.LastIndex(path, "[")
+		if i < 0 {
+			break
+		}
+		path = path[:i]
+	}
+	return path[strings.LastIndex(path, ".")+1:]
+}
+
+// newError creates the error reported by a built-in rule, rendering its English message.
+func newError(rule, code, key string, value interface{}, params Params) *ValidationError {
+	message, _ := DefaultCatalog.Translate("en", key, params)
+	return &ValidationError{Rule: rule, Code: code, Key: key, Params: params, Value: value, Message: message}
+}
+
+// invalid creates the error reported by a built-in rule rejecting a value.
+func invalid(rule, key string, value interface{}, params Params) *ValidationError {
+	return newError(rule, CodeInvalid, key, value, params)
+}
+
+// invalidType creates the error reported by a built-in rule receiving a value of the wrong type.
+func invalidType(rule, key string, value interface{}, params Params) *ValidationError {
+	return newError(rule, CodeInvalidType, key, value, params)
+}
+
+// localize translates the message of a failure carrying a message key into the first known locale.
+func localize(err *ValidationError, translator Translator, locales []string) *ValidationError {
+	if err.Key == "" {
+		return err
+	}
+	params := err.Params
+	// Messages wrapping other failures, such as the ones of Each and AnyOf, embed their translated messages
+	inner, wrapsOne := err.Err.(*ValidationError)
+	errs, wrapsMany := err.Params["errors"].(ValidationErrors)
+	if wrapsOne || wrapsMany {
+		params = Params{}
+		for name, value := range err.Params {
+			params[name] = value
+		}
+	}
+	if wrapsOne {
+		params["error"] = localize(inner, translator, locales).Message
+	}
+	if wrapsMany {
+		localized := make(ValidationErrors, len(errs))
+		for i, e := range errs {
+			localized[i] = e
+			if e, ok := e.(*ValidationError); ok {
+				localized[i] = localize(e, translator, locales)
+			}
+		}
+		params["errors"] = localized
+	}
+	for _, locale := range locales {
+		if message, ok := translator.Translate(locale, err.Key, params); ok {
+			e := *err
+			e.Message = message
+			return &e
+		}
+	}
+	return err
+}
+
+// DefaultCatalog holds the messages of the built-in rules in English, French and Arabic.
+// Add locales or override messages by modifying it, or pass another Translator with WithTranslator.
+var DefaultCatalog = Catalog{
+	"en": {
+		"required":            "{field} is required",
+		"null":                "{field} must not be null",
+		"unknown_key":         "{field} is not allowed",
+		"object":              "'{field}' must be an object",
+		"nil":                 "value is nil",
+		"empty":               "value is empty",
+		"zero":                "value is zero",
+		"false":               "value is false",
+		"not_string":          "value is not a string",
+		"string":              "value must be a string",
+		"alphanumeric_chars":  "value contains invalid characters",
+		"email":               "value is not a valid email address",
+		"one_of":              "value must be one of {values}",
+		"not_one_of":          "value must not be one of {values}",
+		"array_param":         "expected an array or slice, got {type}",
+		"number":              "value must be a number",
+		"integer":             "value must be an integer",
+		"float":               "value must be a float",
+		"boolean":             "value must be a boolean",
+		"type":                "value must be of type {type}",
+		"convert_int":         "value cannot be converted to an integer",
+		"convert_float":       "value cannot be converted to a number",
+		"convert_bool":        "value cannot be converted to a boolean",
+		"convert_decimal":     "value cannot be converted to a decimal",
+		"decode_type":         "value cannot be decoded into {type}",
+		"slice":               "value must be a slice",
+		"map":                 "value must be a map",
+		"url":                 "value is not a valid URL",
+		"uuid":                "value is not a valid UUID",
+		"date":                "value is not a valid date (expected format: YYYY-MM-DD)",
+		"time":                "value is not a valid time (expected format: HH:MM:SS)",
+		"credit_card":         "value is not a valid credit card number",
+		"hex_color":           "value is not a valid hexadecimal color code",
+		"json":                "value is not valid JSON",
+		"ip":                  "value is not a valid IP address",
+		"alpha":               "value must contain only alphabetic characters",
+		"alphanumeric":        "value must contain only alphanumeric characters",
+		"arabic_error":        "an error occurred while validating the string",
+		"arabic":              "value must contain only Arabic characters",
+		"alpha_arabic":        "value must contain only Arabic and Latin alphabetic characters",
+		"base64":              "value is not valid Base64",
+		"base64_image_prefix": "invalid base64 image format: must start with 'data:image/'",
+		"base64_image_data":   "invalid base64 image format: missing data prefix",
+		"base64_image":        "value is not valid Base64 image",
+		"image_format":        "invalid image format",
+		"pattern":             "value does not match the required pattern",
+		"min_length_string":   "value must be at least {min} characters long",
+		"min_length_items":    "value must have at least {min} elements",
+		"max_length_string":   "value must be at most {max} characters long",
+		"max_length_items":    "value must have at most {max} elements",
+		"length_type":         "value must be a string, slice, or array, got {type}",
+		"length":              "value must be between {min} and {max} characters long",
+		"max":                 "value must be less than or equal to {max}",
+		"min":                 "value must be greater than or equal to {min}",
+		"between":             "value must be between {min} and {max}",
+		"greater_than":        "value must be greater than {min}",
+		"less_than":           "value must be less than {max}",
+		"decimal":             "value must be a decimal number",
+		"decimal_places":      "value must have at most {places} decimal places",
+		"multiple_of":         "value must be a multiple of {step}",
+		"precision":           "value must have at most {total} digits, {scale} of them after the decimal point",
+		"slice_or_array":      "value must be a slice or array",
+		"each":                "element at index {index}: {error}",
+		"map_key":             "key {key}: {error}",
+		"min_properties":      "value must have at least {min} properties",
+		"max_properties":      "value must have at most {max} properties",
+		"nil_slice":           "value must be a non-nil slice or array",
+		"slice_or_array_type": "value must be a slice or array, got {type}",
+		"element_object":      "element at index {index} must be an object, got {type}",
+		"unique_items":        "value duplicates the element at index {index}",
+		"unique_by":           "{key} must be unique, the element at index {index} has the same value",
+		"contains":            "value must contain a matching element",
+		"contains_min":        "value must contain at least {min} matching elements, got {count}",
+		"contains_max":        "value must contain at most {max} matching elements, got {count}",
+		"sorted_asc":          "value must be sorted in ascending order",
+		"sorted_desc":         "value must be sorted in descending order",
+		"sort_type":           "element at index {index} cannot be compared with the previous one",
+		"comparison_required": "{other} is required for comparison",
+		"not_comparable":      "value cannot be compared with {other}",
+		"equals_field":        "value must be equal to {other}",
+		"not_equals_field":    "value must not be equal to {other}",
+		"gt_field":            "value must be greater than {other}",
+		"gte_field":           "value must be greater than or equal to {other}",
+		"lt_field":            "value must be less than {other}",
+		"lte_field":           "value must be less than or equal to {other}",
+		"required_if":         "{field} is required when {other} is one of {values}",
+		"required_unless":     "{field} is required unless {other} is one of {values}",
+		"required_with":       "{field} is required when {other} is present",
+		"excluded_if":         "{field} must not be present when {other} is one of {values}",
+		"any_of":              "value matches none of the alternatives: {errors}",
+		"not":                 "value is not allowed",
+		"discriminator":       "{field} must be one of {values}",
+		"max_depth":           "value is nested deeper than {max} levels",
+		"branch":              "{branch} ({errors})",
+	},
+	"fr": {
+		"required":            "{field} est obligatoire",
+		"null":                "{field} ne doit pas être nul",
+		"unknown_key":         "{field} n'est pas autorisé",
+		"object":              "'{field}' doit être un objet",
+		"nil":                 "la valeur est nulle",
+		"empty":               "la valeur est vide",
+		"zero":                "la valeur est zéro",
+		"false":               "la valeur est fausse",
+		"not_string":          "la valeur n'est pas une chaîne de caractères",
+		"string":              "la valeur doit être une chaîne de caractères",
+		"alphanumeric_chars":  "la valeur contient des caractères invalides",
+		"email":               "la valeur n'est pas une adresse e-mail valide",
+		"one_of":              "la valeur doit être l'une des suivantes : {values}",
+		"not_one_of":          "la valeur ne doit pas être l'une des suivantes : {values}",
+		"array_param":         "un tableau ou une liste est attendu, {type} reçu",
+		"number":              "la valeur doit être un nombre",
+		"integer":             "la valeur doit être un entier",
+		"float":               "la valeur doit être un nombre décimal",
+		"boolean":             "la valeur doit être un booléen",
+		"type":                "la valeur doit être de type {type}",
+		"convert_int":         "la valeur ne peut pas être convertie en entier",
+		"convert_float":       "la valeur ne peut pas être convertie en nombre",
+		"convert_bool":        "la valeur ne peut pas être convertie en booléen",
+		"convert_decimal":     "la valeur ne peut pas être convertie en nombre décimal",
+		"decode_type":         "la valeur ne peut pas être décodée en {type}",
+		"slice":               "la valeur doit être une liste",
+		"map":                 "la valeur doit être un objet",
+		"url":                 "la valeur n'est pas une URL valide",
+		"uuid":                "la valeur n'est pas un UUID valide",
+		"date":                "la valeur n'est pas une date valide (format attendu : AAAA-MM-JJ)",
+		"time":                "la valeur n'est pas une heure valide (format attendu : HH:MM:SS)",
+		"credit_card":         "la valeur n'est pas un numéro de carte bancaire valide",
+		"hex_color":           "la valeur n'est pas un code couleur hexadécimal valide",
+		"json":                "la valeur n'est pas un JSON valide",
+		"ip":                  "la valeur n'est pas une adresse IP valide",
+		"alpha":               "la valeur ne doit contenir que des lettres",
+		"alphanumeric":        "la valeur ne doit contenir que des lettres et des chiffres",
+		"arabic_error":        "une erreur est survenue lors de la validation de la chaîne",
+		"arabic":              "la valeur ne doit contenir que des caractères arabes",
+		"alpha_arabic":        "la valeur ne doit contenir que des lettres arabes et latines",
+		"base64":              "la valeur n'est pas un Base64 valide",
+		"base64_image_prefix": "format d'image base64 invalide : doit commencer par 'data:image/'",
+		"base64_image_data":   "format d'image base64 invalide : préfixe de données manquant",
+		"base64_image":        "la valeur n'est pas une image Base64 valide",
+		"image_format":        "format d'image invalide",
+		"pattern":             "la valeur ne correspond pas au format requis",
+		"min_length_string":   "la valeur doit contenir au moins {min} caractères",
+		"min_length_items":    "la valeur doit contenir au moins {min} éléments",
+		"max_length_string":   "la valeur doit contenir au plus {max} caractères",
+		"max_length_items":    "la valeur doit contenir au plus {max} éléments",
+		"length_type":         "la valeur doit être une chaîne ou une liste, {type} reçu",
+		"length":              "la valeur doit contenir entre {min} et {max} caractères",
+		"max":                 "la valeur doit être inférieure ou égale à {max}",
+		"min":                 "la valeur doit être supérieure ou égale à {min}",
+		"between":             "la valeur doit être comprise entre {min} et {max}",
+		"greater_than":        "la valeur doit être strictement supérieure à {min}",
+		"less_than":           "la valeur doit être strictement inférieure à {max}",
+		"decimal":             "la valeur doit être un nombre décimal",
+		"decimal_places":      "la valeur doit avoir au plus {places} décimales",
+		"multiple_of":         "la valeur doit être un multiple de {step}",
+		"precision":           "la valeur doit avoir au plus {total} chiffres, dont {scale} après la virgule",
+		"slice_or_array":      "la valeur doit être une liste",
+		"each":                "élément à l'index {index} : {error}",
+		"map_key":             "clé {key} : {error}",
+		"min_properties":      "la valeur doit avoir au moins {min} propriétés",
+		"max_properties":      "la valeur doit avoir au plus {max} propriétés",
+		"nil_slice":           "la valeur doit être une liste non nulle",
+		"slice_or_array_type": "la valeur doit être une liste, {type} reçu",
+		"element_object":      "l'élément à l'index {index} doit être un objet, {type} reçu",
+		"unique_items":        "la valeur est un doublon de l'élément à l'index {index}",
+		"unique_by":           "{key} doit être unique, l'élément à l'index {index} a la même valeur",
+		"contains":            "la valeur doit contenir un élément correspondant",
+		"contains_min":        "la valeur doit contenir au moins {min} éléments correspondants, {count} trouvés",
+		"contains_max":        "la valeur doit contenir au plus {max} éléments correspondants, {count} trouvés",
+		"sorted_asc":          "la valeur doit être triée par ordre croissant",
+		"sorted_desc":         "la valeur doit être triée par ordre décroissant",
+		"sort_type":           "l'élément à l'index {index} ne peut pas être comparé au précédent",
+		"comparison_required": "{other} est obligatoire pour la comparaison",
+		"not_comparable":      "la valeur ne peut pas être comparée à {other}",
+		"equals_field":        "la valeur doit être égale à {other}",
+		"not_equals_field":    "la valeur doit être différente de {other}",
+		"gt_field":            "la valeur doit être supérieure à {other}",
+		"gte_field":           "la valeur doit être supérieure ou égale à {other}",
+		"lt_field":            "la valeur doit être inférieure à {other}",
+		"lte_field":           "la valeur doit être inférieure ou égale à {other}",
+		"required_if":         "{field} est obligatoire lorsque {other} vaut l'une des valeurs {values}",
+		"required_unless":     "{field} est obligatoire sauf si {other} vaut l'une des valeurs {values}",
+		"required_with":       "{field} est obligatoire lorsque {other} est présent",
+		"excluded_if":         "{field} ne doit pas être présent lorsque {other} vaut l'une des valeurs {values}",
+		"any_of":              "la valeur ne correspond à aucune des alternatives : {errors}",
+		"not":                 "la valeur n'est pas autorisée",
+		"discriminator":       "{field} doit être l'une des suivantes : {values}",
+		"max_depth":           "la valeur est imbriquée sur plus de {max} niveaux",
+		"branch":              "{branch} ({errors})",
+	},
+	"ar": {
+		"required":            "الحقل {field} مطلوب",
+		"null":                "الحقل {field} لا يقبل القيمة null",
+		"unknown_key":         "الحقل {field} غير مسموح به",
+		"object":              "يجب أن يكون '{field}' كائنًا",
+		"nil":                 "القيمة فارغة (null)",
+		"empty":               "القيمة فارغة",
+		"zero":                "القيمة تساوي صفرًا",
+		"false":               "القيمة خاطئة",
+		"not_string":          "القيمة ليست نصًا",
+		"string":              "يجب أن تكون القيمة نصًا",
+		"alphanumeric_chars":  "تحتوي القيمة على أحرف غير صالحة",
+		"email":               "القيمة ليست عنوان بريد إلكتروني صالحًا",
+		"one_of":              "يجب أن تكون القيمة إحدى القيم التالية: {values}",
+		"not_one_of":          "يجب ألا تكون القيمة إحدى القيم التالية: {values}",
+		"array_param":         "كان متوقعًا مصفوفة، ولكن تم استلام {type}",
+		"number":              "يجب أن تكون القيمة رقمًا",
+		"integer":             "يجب أن تكون القيمة عددًا صحيحًا",
+		"float":               "يجب أن تكون القيمة عددًا عشريًا",
+		"boolean":             "يجب أن تكون القيمة منطقية",
+		"type":                "يجب أن تكون القيمة من النوع {type}",
+		"convert_int":         "لا يمكن تحويل القيمة إلى عدد صحيح",
+		"convert_float":       "لا يمكن تحويل القيمة إلى رقم",
+		"convert_bool":        "لا يمكن تحويل القيمة إلى قيمة منطقية",
+		"convert_decimal":     "لا يمكن تحويل القيمة إلى رقم عشري",
+		"decode_type":         "لا يمكن تحويل القيمة إلى النوع {type}",
+		"slice":               "يجب أن تكون القيمة قائمة",
+		"map":                 "يجب أن تكون القيمة كائنًا",
+		"url":                 "القيمة ليست رابطًا صالحًا",
+		"uuid":                "القيمة ليست معرّف UUID صالحًا",
+		"date":                "القيمة ليست تاريخًا صالحًا (الصيغة المتوقعة: YYYY-MM-DD)",
+		"time":                "القيمة ليست وقتًا صالحًا (الصيغة المتوقعة: HH:MM:SS)",
+		"credit_card":         "القيمة ليست رقم بطاقة ائتمان صالحًا",
+		"hex_color":           "القيمة ليست رمز لون سداسي عشري صالحًا",
+		"json":                "القيمة ليست JSON صالحًا",
+		"ip":                  "القيمة ليست عنوان IP صالحًا",
+		"alpha":               "يجب أن تحتوي القيمة على أحرف أبجدية فقط",
+		"alphanumeric":        "يجب أن تحتوي القيمة على أحرف وأرقام فقط",
+		"arabic_error":        "حدث خطأ أثناء التحقق من النص",
+		"arabic":              "يجب أن تحتوي القيمة على أحرف عربية فقط",
+		"alpha_arabic":        "يجب أن تحتوي القيمة على أحرف عربية ولاتينية فقط",
+		"base64":              "القيمة ليست Base64 صالحًا",
+		"base64_image_prefix": "صيغة صورة base64 غير صالحة: يجب أن تبدأ بـ 'data:image/'",
+		"base64_image_data":   "صيغة صورة base64 غير صالحة: بادئة البيانات مفقودة",
+		"base64_image":        "القيمة ليست صورة Base64 صالحة",
+		"image_format":        "صيغة الصورة غير صالحة",
+		"pattern":             "القيمة لا تطابق النمط المطلوب",
+		"min_length_string":   "يجب أن تحتوي القيمة على {min} أحرف على الأقل",
+		"min_length_items":    "يجب أن تحتوي القيمة على {min} عناصر على الأقل",
+		"max_length_string":   "يجب أن تحتوي القيمة على {max} أحرف على الأكثر",
+		"max_length_items":    "يجب أن تحتوي القيمة على {max} عناصر على الأكثر",
+		"length_type":         "يجب أن تكون القيمة نصًا أو قائمة، ولكن تم استلام {type}",
+		"length":              "يجب أن يكون طول القيمة بين {min} و {max} حرفًا",
+		"max":                 "يجب أن تكون القيمة أقل من أو تساوي {max}",
+		"min":                 "يجب أن تكون القيمة أكبر من أو تساوي {min}",
+		"between":             "يجب أن تكون القيمة بين {min} و {max}",
+		"greater_than":        "يجب أن تكون القيمة أكبر من {min}",
+		"less_than":           "يجب أن تكون القيمة أقل من {max}",
+		"decimal":             "يجب أن تكون القيمة رقمًا عشريًا",
+		"decimal_places":      "يجب ألا تتجاوز القيمة {places} منازل عشرية",
+		"multiple_of":         "يجب أن تكون القيمة من مضاعفات {step}",
+		"precision":           "يجب ألا تتجاوز القيمة {total} أرقام، منها {scale} بعد الفاصلة العشرية",
+		"slice_or_array":      "يجب أن تكون القيمة قائمة",
+		"each":                "العنصر في الموضع {index}: {error}",
+		"map_key":             "المفتاح {key}: {error}",
+		"min_properties":      "يجب أن تحتوي القيمة على {min} خصائص على الأقل",
+		"max_properties":      "يجب ألا تحتوي القيمة على أكثر من {max} خصائص",
+		"nil_slice":           "يجب أن تكون القيمة قائمة غير فارغة",
+		"slice_or_array_type": "يجب أن تكون القيمة قائمة، ولكن تم استلام {type}",
+		"element_object":      "يجب أن يكون العنصر في الموضع {index} كائنًا، ولكن تم استلام {type}",
+		"unique_items":        "القيمة مكررة مع العنصر في الموضع {index}",
+		"unique_by":           "يجب أن تكون قيمة {key} فريدة، العنصر في الموضع {index} له القيمة نفسها",
+		"contains":            "يجب أن تحتوي القيمة على عنصر مطابق",
+		"contains_min":        "يجب أن تحتوي القيمة على {min} عناصر مطابقة على الأقل، وُجد {count}",
+		"contains_max":        "يجب ألا تحتوي القيمة على أكثر من {max} عناصر مطابقة، وُجد {count}",
+		"sorted_asc":          "يجب أن تكون القيمة مرتبة تصاعديًا",
+		"sorted_desc":         "يجب أن تكون القيمة مرتبة تنازليًا",
+		"sort_type":           "لا يمكن مقارنة العنصر في الموضع {index} بالعنصر السابق",
+		"comparison_required": "الحقل {other} مطلوب للمقارنة",
+		"not_comparable":      "لا يمكن مقارنة القيمة مع {other}",
+		"equals_field":        "يجب أن تساوي القيمة {other}",
+		"not_equals_field":    "يجب ألا تساوي القيمة {other}",
+		"gt_field":            "يجب أن تكون القيمة أكبر من {other}",
+		"gte_field":           "يجب أن تكون القيمة أكبر من أو تساوي {other}",
+		"lt_field":            "يجب أن تكون القيمة أقل من {other}",
+		"lte_field":           "يجب أن تكون القيمة أقل من أو تساوي {other}",
+		"required_if":         "الحقل {field} مطلوب عندما تكون قيمة {other} إحدى القيم {values}",
+		"required_unless":     "الحقل {field} مطلوب إلا إذا كانت قيمة {other} إحدى القيم {values}",
+		"required_with":       "الحقل {field} مطلوب عند وجود {other}",
+		"excluded_if":         "يجب ألا يكون الحقل {field} موجودًا عندما تكون قيمة {other} إحدى القيم {values}",
+		"any_of":              "القيمة لا تطابق أيًا من البدائل: {errors}",
+		"not":                 "القيمة غير مسموح بها",
+		"discriminator":       "يجب أن تكون قيمة الحقل {field} إحدى القيم {values}",
+		"max_depth":           "القيمة متداخلة بأكثر من {max} مستويات",
+		"branch":              "{branch} ({errors})",
+	},
+}
@@ -0,0 +1,143 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogTranslate(t *testing.T) {
+	catalog := Catalog{
+		"fr": {"min": "la valeur doit être supérieure ou égale à {min}"},
+	}
+
+	tests := []struct {
+		name     string
+		locale   string
+		key      string
+		expected string
+		found    bool
+	}{
+		{"exact locale", "fr", "min", "la valeur doit être supérieure ou égale à 3", true},
+		{"regional locale", "fr-CA", "min", "la valeur doit être supérieure ou égale à 3", true},
+		{"underscore locale", "FR_fr", "min", "la valeur doit être supérieure ou égale à 3", true},
+		{"unknown locale", "de", "min", "", false},
+		{"unknown key", "fr", "max", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			message, found := catalog.Translate(test.locale, test.key, Params{"min": 3})
+			require.Equal(t, test.found, found)
+			require.Equal(t, test.expected, message)
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	require.Equal(t, "between 1 and 5", render("between {min} and {max}", Params{"min": 1, "max": 5}))
+	require.Equal(t, "keeps {unknown} placeholders", render("keeps {unknown} placeholders", nil))
+	require.Equal(t, "unclosed {min", render("unclosed {min", Params{"min": 1}))
+}
+
+func TestDefaultCatalogIsComplete(t *testing.T) {
+	for locale, messages := range DefaultCatalog {
+		for key := range DefaultCatalog["en"] {
+			require.Contains(t, messages, key, "locale %s is missing %s", locale, key)
+		}
+	}
+}
+
+func TestWithLocale(t *testing.T) {
+	options := []ValidationOption{
+		{
+			Key: "password",
+			Validators: []Validator{
+				CreateValidator(MinLength(6), ""),
+			},
+		},
+		{
+			Key: "email",
+			Validators: []Validator{
+				CreateValidator(IsEmail, "Invalid email address"),
+			},
+		},
+		{
+			Key: "tags",
+			Validators: []Validator{
+				CreateValidator(Each(IsString), ""),
+			},
+		},
+		{Key: "name"},
+	}
+	body := func() map[string]interface{} {
+		return map[string]interface{}{"password": "pass", "email": "invalid", "tags": []interface{}{1}}
+	}
+
+	tests := []struct {
+		name     string
+		opts     []RunOption
+		expected string
+	}{
+		{"english by default", nil, "value must be at least 6 characters long; Invalid email address; element at index 0: value must be a string; name is required"},
+		{"french", []RunOption{WithLocale("fr-FR")}, "la valeur doit contenir au moins 6 caractères; Invalid email address; élément à l'index 0 : la valeur doit être une chaîne de caractères; name est obligatoire"},
+		{"arabic", []RunOption{WithLocale("ar")}, "يجب أن تحتوي القيمة على 6 أحرف على الأقل; Invalid email address; العنصر في الموضع 0: يجب أن تكون القيمة نصًا; الحقل name مطلوب"},
+		{"first known locale", []RunOption{WithLocale("de", "fr")}, "la valeur doit contenir au moins 6 caractères; Invalid email address; élément à l'index 0 : la valeur doit être une chaîne de caractères; name est obligatoire"},
+		{"unknown locale", []RunOption{WithLocale("de")}, "value must be at least 6 characters long; Invalid email address; element at index 0: value must be a string; name is required"},
+		{"custom translator", []RunOption{WithLocale("de"), WithTranslator(Catalog{"de": {"required": "{field} ist erforderlich"}})}, "value must be at least 6 characters long; Invalid email address; element at index 0: value must be a string; name ist erforderlich"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateAll(body(), options)
+			if test.opts != nil {
+				err = ValidateContext(context.Background(), body(), options, append(test.opts, CollectAll())...)
+			}
+			require.EqualError(t, err, test.expected)
+		})
+	}
+
+	t.Run("keys and params are reported", func(t *testing.T) {
+		err := Validate(body(), options)
+		var ve *ValidationError
+		require.ErrorAs(t, err, &ve)
+		require.Equal(t, "min_length_string", ve.Key)
+		require.Equal(t, Params{"min": 6}, ve.Params)
+	})
+}
+
+func TestMessageTemplates(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator Validator
+		value     interface{}
+		expected  string
+	}{
+		{"rule parameters", CreateValidator(MinLength(6), "{field} must be at least {min} characters (got {len})"), "pass", "password must be at least 6 characters (got 4)"},
+		{"value and error", CreateValidator(IsEmail, "{value} is invalid: {error}"), "nope", "nope is invalid: value is not a valid email address"},
+		{"escaped braces", CreateValidator(Max(10), "{{max}} is {max}, got {{{value}}}"), 12, "{max} is 10, got {12}"},
+		{"unknown placeholders", CreateValidator(IsNotEmpty, "{unknown} {field}"), "", "{unknown} password"},
+		{"custom validator", CreateValidator(func(value interface{}) error { return errors.New("boom") }, "{path}: {error}"), "x", "password: boom"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := []ValidationOption{{Key: "password", Validators: []Validator{test.validator}}}
+			err := Validate(map[string]interface{}{"password": test.value}, options)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+
+	t.Run("nested paths and builder messages", func(t *testing.T) {
+		options := Object(
+			Field("items").ArrayOf(
+				Field("sku").Length(3, 8, "{path} ({field}) must have {min} to {max} characters"),
+			),
+		)
+		body := map[string]interface{}{"items": []interface{}{map[string]interface{}{"sku": "A"}}}
+		// Elements are validated on their own, so paths are relative to the element
+		require.EqualError(t, Validate(body, options), "sku (sku) must have 3 to 8 characters")
+	})
+}
@@ -0,0 +1,358 @@
+package validator
+
+import (
+	"cmp"
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// decimalRegex matches plain decimal strings such as "-12.50", jsonNumberRegex the number literals of JSON
+	decimalRegex    = regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?$`)
+	jsonNumberRegex = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+	five            = big.NewInt(5)
+)
+
+// maxDecimalExponent is the largest exponent, in absolute value, of a json.Number taken as a number.
+const maxDecimalExponent = 1000
+
+// numberKind is the representation of a number.
+type numberKind int
+
+const (
+	intNumber   numberKind = iota + 1 // Signed integer in i
+	uintNumber                        // Unsigned integer in u
+	floatNumber                       // Float in f, possibly infinite or NaN
+	bigNumber                         // Exact rational in r, for json.Number and math/big values
+)
+
+// number is a numeric value converted to a representation that compares exactly with the others.
+// Go integers and floats are compared without allocating, json.Number and math/big values through big.Rat.
+type number struct {
+	kind numberKind
+	i    int64
+	u    uint64
+	f    float64
+	r    *big.Rat
+}
+
+// toNumber converts integers, floats, json.Number, *big.Int, *big.Float and *big.Rat to a number.
+// A json.Number must be a JSON number literal whose exponent is within maxDecimalExponent, see parseNumber.
+func toNumber(value interface{}) (number, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return number{kind: intNumber, i: i}, true
+		}
+		if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return number{kind: uintNumber, u: u}, true
+		}
+		if r, ok := parseNumber(v); ok {
+			return number{kind: bigNumber, r: r}, true
+		}
+		return number{}, false
+	case *big.Int:
+		if v == nil {
+			return number{}, false
+		}
+		return number{kind: bigNumber, r: new(big.Rat).SetInt(v)}, true
+	case *big.Float:
+		if v == nil {
+			return number{}, false
+		}
+		if v.IsInf() {
+			return number{kind: floatNumber, f: math.Inf(v.Sign())}, true
+		}
+		r, _ := v.Rat(nil)
+		return number{kind: bigNumber, r: r}, true
+	case *big.Rat:
+		if v == nil {
+			return number{}, false
+		}
+		return number{kind: bigNumber, r: v}, true
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return number{kind: intNumber, i: rv.Int()}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return number{kind: uintNumber, u: rv.Uint()}, true
+	case reflect.Float32, reflect.Float64:
+		return number{kind: floatNumber, f: rv.Float()}, true
+	}
+	return number{}, false
+}
+
+// isInteger reports whether value is a Go integer, a *big.Int or a json.Number integer literal.
+func isInteger(value interface{}) bool {
+	switch v := value.(type) {
+	case json.Number:
+		_, ok := new(big.Int).SetString(string(v), 10)
+		return ok
+	case *big.Int:
+		return v != nil
+	}
+	n, ok := toNumber(value)
+	return ok && (n.kind == intNumber || n.kind == uintNumber)
+}
+
+// isFloat reports whether value is a Go float, a *big.Float or a json.Number that is not an integer literal.
+func isFloat(value interface{}) bool {
+	switch v := value.(type) {
+	case json.Number:
+		_, ok := toNumber(v)
+		return ok && !isInteger(v)
+	case *big.Float:
+		return v != nil
+	}
+	n, ok := toNumber(value)
+	return ok && n.kind == floatNumber
+}
+
+// compareNumbers compares two numbers exactly and returns -1, 0 or 1, or false if either is not a number or is NaN.
+func compareNumbers(a, b interface{}) (int, bool) {
+	x, ok := toNumber(a)
+	if !ok {
+		return 0, false
+	}
+	y, ok := toNumber(b)
+	if !ok {
+		return 0, false
+	}
+	return x.cmp(y)
+}
+
+// cmp compares n with m exactly.
+func (n number) cmp(m number) (int, bool) {
+	if n.kind == floatNumber && math.IsNaN(n.f) || m.kind == floatNumber && math.IsNaN(m.f) {
+		return 0, false
+	}
+	switch {
+	case n.kind == m.kind && n.kind != bigNumber:
+		switch n.kind {
+		case intNumber:
+			return cmp.Compare(n.i, m.i), true
+		case uintNumber:
+			return cmp.Compare(n.u, m.u), true
+		default:
+			return cmp.Compare(n.f, m.f), true
+		}
+	case n.kind == intNumber && m.kind == uintNumber:
+		if n.i < 0 {
+			return -1, true
+		}
+		return cmp.Compare(uint64(n.i), m.u), true
+	case n.kind == intNumber && m.kind == floatNumber:
+		return cmpIntFloat(n.i, m.f), true
+	case n.kind == uintNumber && m.kind == floatNumber:
+		return cmpUintFloat(n.u, m.f), true
+	case n.kind == floatNumber && (m.kind == intNumber || m.kind == uintNumber),
+		n.kind == uintNumber && m.kind == intNumber:
+		c, _ := m.cmp(n)
+		return -c, true
+	case m.kind == floatNumber && math.IsInf(m.f, 0):
+		// Infinities don't fit in big.Rat
+		if n.kind == floatNumber {
+			return cmp.Compare(n.f, m.f), true
+		}
+		return -int(math.Copysign(1, m.f)), true
+	case n.kind == floatNumber && math.IsInf(n.f, 0):
+		return int(math.Copysign(1, n.f)), true
+	}
+	return n.rat().Cmp(m.rat()), true
+}
+
+// cmpIntFloat compares an integer with a float that is not NaN exactly.
+func cmpIntFloat(i int64, f float64) int {
+	if f >= math.MaxInt64 {
+		return -1
+	}
+	if f < math.MinInt64 {
+		return 1
+	}
+	t := math.Trunc(f)
+	if c := cmp.Compare(i, int64(t)); c != 0 {
+		return c
+	}
+	return cmp.Compare(t, f)
+}
+
+// cmpUintFloat compares an unsigned integer with a float that is not NaN exactly.
+func cmpUintFloat(u uint64, f float64) int {
+	if f >= math.MaxUint64 {
+		return -1
+	}
+	if f < 0 {
+		return 1
+	}
+	t := math.Trunc(f)
+	if c := cmp.Compare(u, uint64(t)); c != 0 {
+		return c
+	}
+	return cmp.Compare(t, f)
+}
+
+// rat returns n as a big.Rat, n must be finite.
+func (n number) rat() *big.Rat {
+	switch n.kind {
+	case intNumber:
+		return new(big.Rat).SetInt64(n.i)
+	case uintNumber:
+		return new(big.Rat).SetUint64(n.u)
+	case floatNumber:
+		return new(big.Rat).SetFloat64(n.f)
+	}
+	return n.r
+}
+
+// int64 returns n as an int64 if it is a whole number in range.
+func (n number) int64() (int64, bool) {
+	switch n.kind {
+	case intNumber:
+		return n.i, true
+	case uintNumber:
+		return int64(n.u), n.u <= math.MaxInt64
+	case floatNumber:
+		if n.f != math.Trunc(n.f) || n.f < math.MinInt64 || n.f >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(n.f), true
+	case bigNumber:
+		if n.r.IsInt() && n.r.Num().IsInt64() {
+			return n.r.Num().Int64(), true
+		}
+	}
+	return 0, false
+}
+
+// uint64 returns n as a uint64 if it is a non-negative whole number in range.
+func (n number) uint64() (uint64, bool) {
+	switch n.kind {
+	case intNumber:
+		return uint64(n.i), n.i >= 0
+	case uintNumber:
+		return n.u, true
+	case floatNumber:
+		if n.f != math.Trunc(n.f) || n.f < 0 || n.f >= math.MaxUint64 {
+			return 0, false
+		}
+		return uint64(n.f), true
+	case bigNumber:
+		if n.r.IsInt() && n.r.Num().IsUint64() {
+			return n.r.Num().Uint64(), true
+		}
+	}
+	return 0, false
+}
+
+// float64 returns the float64 nearest to n.
+func (n number) float64() float64 {
+	switch n.kind {
+	case intNumber:
+		return float64(n.i)
+	case uintNumber:
+		return float64(n.u)
+	case floatNumber:
+		return n.f
+	}
+	f, _ := n.r.Float64()
+	return f
+}
+
+// parseNumber parses a JSON number literal into an exact rational. A literal with an exponent beyond maxDecimalExponent,
+// such as 1e-100000, is rejected, since a client could otherwise make every comparison with it expensive.
+func parseNumber(n json.Number) (*big.Rat, bool) {
+	if !jsonNumberRegex.MatchString(string(n)) {
+		return nil, false
+	}
+	if i := strings.IndexAny(string(n), "eE"); i >= 0 {
+		exp, err := strconv.Atoi(string(n[i+1:]))
+		if err != nil || exp > maxDecimalExponent || exp < -maxDecimalExponent {
+			return nil, false
+		}
+	}
+	return new(big.Rat).SetString(string(n))
+}
+
+// toDecimal converts decimal strings, json.Number and finite numbers to an exact rational with a finite decimal representation.
+// A json.Number with an exponent beyond maxDecimalExponent, such as 1e-100000, is rejected.
+// Floats are converted through their shortest decimal representation, so that 0.1 is exactly one tenth.
+func toDecimal(value interface{}) (*big.Rat, bool) {
+	switch v := value.(type) {
+	case string:
+		if !decimalRegex.MatchString(v) {
+			return nil, false
+		}
+		return new(big.Rat).SetString(v)
+	case json.Number:
+		return parseNumber(v)
+	}
+	n, ok := toNumber(value)
+	if !ok {
+		return nil, false
+	}
+	if n.kind == floatNumber {
+		if math.IsNaN(n.f) || math.IsInf(n.f, 0) {
+			return nil, false
+		}
+		bits := 64
+		if reflect.TypeOf(value).Kind() == reflect.Float32 {
+			bits = 32
+		}
+		return new(big.Rat).SetString(strconv.FormatFloat(n.f, 'g', -1, bits))
+	}
+	r := n.rat()
+	if _, ok := decimalPlaces(r); !ok {
+		// A *big.Rat such as 1/3
+		return nil, false
+	}
+	return r, true
+}
+
+// decimalPlaces returns the number of digits after the decimal point of r, ignoring trailing zeros,
+// or false if r has no finite decimal representation such as 1/3.
+func decimalPlaces(r *big.Rat) (int, bool) {
+	d := new(big.Int).Set(r.Denom())
+	twos := int(d.TrailingZeroBits())
+	d.Rsh(d, uint(twos))
+	// Dividing by 5^(2^k) from the largest k down takes a number of divisions logarithmic in the number of fives
+	powers := []*big.Int{five}
+	for {
+		next := new(big.Int).Mul(powers[len(powers)-1], powers[len(powers)-1])
+		if next.Cmp(d) > 0 {
+			break
+		}
+		powers = append(powers, next)
+	}
+	fives := 0
+	q, m := new(big.Int), new(big.Int)
+	for k := len(powers) - 1; k >= 0; k-- {
+		q.QuoRem(d, powers[k], m)
+		if m.Sign() == 0 {
+			d, q = q, d
+			fives += 1 << k
+		}
+	}
+	return max(twos, fives), d.IsInt64() && d.Int64() == 1
+}
+
+// integerDigits returns the number of digits before the decimal point of r, 0 for numbers between -1 and 1.
+func integerDigits(r *big.Rat) int {
+	i := new(big.Int).Quo(r.Num(), r.Denom())
+	if i.Sign() == 0 {
+		return 0
+	}
+	return len(i.Abs(i).String())
+}
+
+// formatDecimal formats r, which must have a finite decimal representation, with at least places decimal places.
+func formatDecimal(r *big.Rat, places int) string {
+	exact, _ := decimalPlaces(r)
+	return r.FloatString(max(exact, places))
+}
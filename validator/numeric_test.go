@@ -0,0 +1,156 @@
+package validator
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareNumbers(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   interface{}
+		result int
+		ok     bool
+	}{
+		{"int and float", 3, 2.5, 1, true},
+		{"int above float64 precision", int64(1<<53 + 1), float64(1 << 53), 1, true},
+		{"negative float and int", -2.5, -2, -1, true},
+		{"negative int and uint", -1, uint(0), -1, true},
+		{"large uints", uint64(math.MaxUint64), uint64(math.MaxUint64 - 1), 1, true},
+		{"uint and float beyond uint64", uint64(math.MaxUint64), 1e20, -1, true},
+		{"uint and int64", uint64(1 << 63), int64(math.MaxInt64), 1, true},
+		{"json.Number and int", json.Number("9007199254740993"), 9007199254740992, 1, true},
+		{"decimal json.Number and float", json.Number("0.1"), 0.1, -1, true},
+		{"exponent json.Number and int", json.Number("1e3"), 1000, 0, true},
+		{"big.Int and uint64", new(big.Int).Lsh(big.NewInt(1), 64), uint64(math.MaxUint64), 1, true},
+		{"big.Rat and float", big.NewRat(1, 3), 0.3333, 1, true},
+		{"infinity and big.Int", math.Inf(1), new(big.Int).Lsh(big.NewInt(1), 2000), 1, true},
+		{"big.Int and negative infinity", big.NewInt(0), math.Inf(-1), 1, true},
+		{"int and infinity", math.MinInt64, math.Inf(-1), 1, true},
+		{"NaN", math.NaN(), 1, 0, false},
+		{"invalid json.Number", json.Number("x"), 1, 0, false},
+		{"nil big.Int", (*big.Int)(nil), 1, 0, false},
+		{"not a number", "1", 1, 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, ok := compareNumbers(test.a, test.b)
+			require.Equal(t, test.ok, ok)
+			require.Equal(t, test.result, result)
+			if ok {
+				reversed, _ := compareNumbers(test.b, test.a)
+				require.Equal(t, -test.result, reversed)
+			}
+		})
+	}
+}
+
+func TestNumbersFromJSON(t *testing.T) {
+	var body map[string]interface{}
+	decoder := json.NewDecoder(strings.NewReader(`{"id": 18446744073709551615, "amount": "12", "total": 12345678901234567890123, "price": 9.99}`))
+	decoder.UseNumber()
+	require.NoError(t, decoder.Decode(&body))
+
+	options := Object(
+		Field("id").Int().Min(1),
+		Field("total").Int().Max(1e22),
+		Field("price").Float().Min(0),
+	)
+	require.EqualError(t, ValidateAll(body, options), "value must be less than or equal to 1e+22")
+
+	type order struct {
+		ID    uint64   `json:"id"`
+		Total *big.Int `json:"total"`
+		Price float64  `json:"price"`
+	}
+	result, err := Decode[order](body)
+	require.NoError(t, err)
+	require.Equal(t, uint64(math.MaxUint64), result.ID)
+	require.Equal(t, "12345678901234567890123", result.Total.String())
+	require.Equal(t, 9.99, result.Price)
+
+	_, err = Decode[struct {
+		ID int64 `json:"id"`
+	}](body)
+	require.EqualError(t, err, "value cannot be decoded into int64")
+	_, err = Decode[struct {
+		Price string `json:"price"`
+	}](body)
+	require.EqualError(t, err, "value cannot be decoded into string")
+
+	require.NoError(t, NumberMax[uint64](math.MaxUint64).Func()(body["id"]))
+	require.EqualError(t, NumberMax[int64](0).Func()(body["id"]), "value must be of type int64")
+}
+
+func TestDecimalPlaces(t *testing.T) {
+	pow := func(base, exp int64) *big.Int {
+		return new(big.Int).Exp(big.NewInt(base), big.NewInt(exp), nil)
+	}
+	tests := []struct {
+		name   string
+		r      *big.Rat
+		places int
+		ok     bool
+	}{
+		{"integer", big.NewRat(12, 1), 0, true},
+		{"cents", big.NewRat(1234, 100), 2, true},
+		{"halves", big.NewRat(1, 2), 1, true},
+		{"fives", new(big.Rat).SetFrac(big.NewInt(3), pow(5, 777)), 777, true},
+		{"twos and fives", new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Mul(pow(2, 9), pow(5, 4))), 9, true},
+		{"large power of ten", new(big.Rat).SetFrac(big.NewInt(1), pow(10, 100000)), 100000, true},
+		{"thirds", big.NewRat(1, 3), 0, false},
+		{"fives and a three", new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Mul(pow(5, 20), big.NewInt(3))), 20, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			places, ok := decimalPlaces(test.r)
+			require.Equal(t, test.ok, ok)
+			if ok {
+				require.Equal(t, test.places, places)
+			}
+		})
+	}
+
+	t.Run("huge exponents are rejected quickly", func(t *testing.T) {
+		start := time.Now()
+		for _, value := range []interface{}{
+			json.Number("1e-1000000"),
+			json.Number("1e1000000"),
+			json.Number("1e-99999999999999999999"),
+			"0." + strings.Repeat("0", 100000) + "1",
+		} {
+			require.Error(t, MaxDecimalPlaces(2)(value))
+		}
+		require.NoError(t, MaxDecimalPlaces(1000)(json.Number("1e-1000")))
+		require.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("huge exponents are not numbers", func(t *testing.T) {
+		type order struct {
+			Quantity float64 `json:"quantity"`
+		}
+		start := time.Now()
+		for range 100 {
+			for _, value := range []json.Number{"1e999999", "-1E-999999", "1e+1001"} {
+				require.Error(t, Min(5)(value))
+				require.Error(t, Max(5)(value))
+				require.Error(t, IsNumber(value))
+				_, ok := compareNumbers(value, 1)
+				require.False(t, ok)
+				_, err := Decode[order](map[string]interface{}{"quantity": value})
+				require.Error(t, err)
+			}
+		}
+		require.Less(t, time.Since(start), time.Second)
+		require.NoError(t, Min(5)(json.Number("1e1000")))
+		require.NoError(t, IsNumber(json.Number("-2.5E-3")))
+		require.Error(t, IsNumber(json.Number("0x10")))
+	})
+}
@@ -1,8 +1,9 @@
 package validator
 
 import (
-	"errors"
+	"context"
 	"fmt"
+	"math/big"
 	"reflect"
 )
 
@@ -10,21 +11,21 @@ import (
 func MinLength(min int) ValidatorFunc {
 	return func(value interface{}) error {
 		if value == nil {
-			return errors.New("value is nil")
+			return invalidType("MinLength", "nil", value, nil)
 		}
 
 		v := reflect.ValueOf(value)
 		switch v.Kind() {
 		case reflect.String:
 			if v.Len() < min {
-				return fmt.Errorf("value must be at least %d characters long", min)
+				return invalid("MinLength", "min_length_string", value, Params{"min": min})
 			}
 		case reflect.Slice, reflect.Array:
 			if v.Len() < min {
-				return fmt.Errorf("value must have at least %d elements", min)
+				return invalid("MinLength", "min_length_items", value, Params{"min": min})
 			}
 		default:
-			return fmt.Errorf("value must be a string, slice, or array, got %T", value)
+			return invalidType("MinLength", "length_type", value, Params{"type": fmt.Sprintf("%T", value)})
 		}
 		return nil
 	}
@@ -34,21 +35,21 @@ func MinLength(min int) ValidatorFunc {
 func MaxLength(max int) ValidatorFunc {
 	return func(value interface{}) error {
 		if value == nil {
-			return errors.New("value is nil")
+			return invalidType("MaxLength", "nil", value, nil)
 		}
 
 		v := reflect.ValueOf(value)
 		switch v.Kind() {
 		case reflect.String:
 			if v.Len() > max {
-				return fmt.Errorf("value must be at most %d characters long", max)
+				return invalid("MaxLength", "max_length_string", value, Params{"max": max})
 			}
 		case reflect.Slice, reflect.Array:
 			if v.Len() > max {
-				return fmt.Errorf("value must have at most %d elements", max)
+				return invalid("MaxLength", "max_length_items", value, Params{"max": max})
 			}
 		default:
-			return fmt.Errorf("value must be a string, slice, or array, got %T", value)
+			return invalidType("MaxLength", "length_type", value, Params{"type": fmt.Sprintf("%T", value)})
 		}
 		return nil
 	}
@@ -57,53 +58,176 @@ func MaxLength(max int) ValidatorFunc {
 // Length checks if a string meets a length requirement within a range.
 func Length(min, max int) ValidatorFunc {
 	return func(value interface{}) error {
+		if value == nil {
+			return invalidType("Length", "nil", value, nil)
+		}
 		str, ok := value.(string)
 		if !ok {
-			return errors.New("value is not a string")
+			return invalidType("Length", "not_string", value, nil)
 		}
 		length := len(str)
 		if length < min || length > max {
-			return fmt.Errorf("value must be between %d and %d characters long", min, max)
+			return invalid("Length", "length", value, Params{"min": min, "max": max})
 		}
 		return nil
 	}
 }
 
 // MaxValue checks if a numeric value is less than or equal to a maximum value.
+// Integers of any size, json.Number and math/big values are compared exactly.
 func Max(max float64) ValidatorFunc {
 	return func(value interface{}) error {
-		v := reflect.ValueOf(value)
-		switch v.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			if float64(v.Int()) > max {
-				return fmt.Errorf("value must be less than or equal to %v", max)
-			}
-		case reflect.Float32, reflect.Float64:
-			if v.Float() > max {
-				return fmt.Errorf("value must be less than or equal to %v", max)
-			}
-		default:
-			return errors.New("value must be a number")
+		if value == nil {
+			return invalidType("Max", "nil", value, nil)
+		}
+		c, ok := compareNumbers(value, max)
+		if !ok {
+			return invalidType("Max", "number", value, nil)
+		}
+		if c > 0 {
+			return invalid("Max", "max", value, Params{"max": max})
 		}
 		return nil
 	}
 }
 
 // MinValue checks if a numeric value is greater than or equal to a minimum value.
+// Integers of any size, json.Number and math/big values are compared exactly.
 func Min(min float64) ValidatorFunc {
 	return func(value interface{}) error {
-		v := reflect.ValueOf(value)
-		switch v.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			if float64(v.Int()) < min {
-				return fmt.Errorf("value must be greater than or equal to %v", min)
-			}
-		case reflect.Float32, reflect.Float64:
-			if v.Float() < min {
-				return fmt.Errorf("value must be greater than or equal to %v", min)
-			}
-		default:
-			return errors.New("value must be a number")
+		if value == nil {
+			return invalidType("Min", "nil", value, nil)
+		}
+		c, ok := compareNumbers(value, min)
+		if !ok {
+			return invalidType("Min", "number", value, nil)
+		}
+		if c < 0 {
+			return invalid("Min", "min", value, Params{"min": min})
+		}
+		return nil
+	}
+}
+
+// decimalBound converts the bound of a decimal validator, panicking on NaN and infinities.
+func decimalBound(rule string, bound float64) *big.Rat {
+	r, ok := toDecimal(bound)
+	if !ok {
+		panic(fmt.Sprintf("Invalid %s bound: %v", rule, bound))
+	}
+	return r
+}
+
+// GreaterThan checks if a number or decimal string is strictly greater than min.
+// Values and bounds are compared as exact decimals, floats through their shortest decimal representation.
+func GreaterThan(min float64) ValidatorFunc {
+	bound := decimalBound("GreaterThan", min)
+	return func(value interface{}) error {
+		if value == nil {
+			return invalidType("GreaterThan", "nil", value, nil)
+		}
+		r, ok := toDecimal(value)
+		if !ok {
+			return invalidType("GreaterThan", "decimal", value, nil)
+		}
+		if r.Cmp(bound) <= 0 {
+			return invalid("GreaterThan", "greater_than", value, Params{"min": min})
+		}
+		return nil
+	}
+}
+
+// LessThan checks if a number or decimal string is strictly less than max.
+// Values and bounds are compared as exact decimals, floats through their shortest decimal representation.
+func LessThan(max float64) ValidatorFunc {
+	bound := decimalBound("LessThan", max)
+	return func(value interface{}) error {
+		if value == nil {
+			return invalidType("LessThan", "nil", value, nil)
+		}
+		r, ok := toDecimal(value)
+		if !ok {
+			return invalidType("LessThan", "decimal", value, nil)
+		}
+		if r.Cmp(bound) >= 0 {
+			return invalid("LessThan", "less_than", value, Params{"max": max})
+		}
+		return nil
+	}
+}
+
+// Between checks if a number or decimal string is between min and max inclusive.
+// Values and bounds are compared as exact decimals, floats through their shortest decimal representation.
+func Between(min, max float64) ValidatorFunc {
+	lower, upper := decimalBound("Between", min), decimalBound("Between", max)
+	return func(value interface{}) error {
+		if value == nil {
+			return invalidType("Between", "nil", value, nil)
+		}
+		r, ok := toDecimal(value)
+		if !ok {
+			return invalidType("Between", "decimal", value, nil)
+		}
+		if r.Cmp(lower) < 0 || r.Cmp(upper) > 0 {
+			return invalid("Between", "between", value, Params{"min": min, "max": max})
+		}
+		return nil
+	}
+}
+
+// MaxDecimalPlaces checks if a number or decimal string has at most places digits after the decimal point.
+// Trailing zeros don't count, so "12.50" has 1 decimal place.
+func MaxDecimalPlaces(places int) ValidatorFunc {
+	return func(value interface{}) error {
+		if value == nil {
+			return invalidType("MaxDecimalPlaces", "nil", value, nil)
+		}
+		r, ok := toDecimal(value)
+		if !ok {
+			return invalidType("MaxDecimalPlaces", "decimal", value, nil)
+		}
+		if n, _ := decimalPlaces(r); n > places {
+			return invalid("MaxDecimalPlaces", "decimal_places", value, Params{"places": places})
+		}
+		return nil
+	}
+}
+
+// MultipleOf checks if a number or decimal string is a whole multiple of step, e.g. of 0.05 for cash amounts.
+// It panics if step is not a positive number.
+func MultipleOf(step float64) ValidatorFunc {
+	divisor := decimalBound("MultipleOf", step)
+	if divisor.Sign() <= 0 {
+		panic(fmt.Sprintf("Invalid MultipleOf step: %v", step))
+	}
+	return func(value interface{}) error {
+		if value == nil {
+			return invalidType("MultipleOf", "nil", value, nil)
+		}
+		r, ok := toDecimal(value)
+		if !ok {
+			return invalidType("MultipleOf", "decimal", value, nil)
+		}
+		if !new(big.Rat).Quo(r, divisor).IsInt() {
+			return invalid("MultipleOf", "multiple_of", value, Params{"step": step})
+		}
+		return nil
+	}
+}
+
+// Precision checks if a number or decimal string fits a SQL NUMERIC(total, scale) column:
+// at most scale digits after the decimal point and total-scale digits before it.
+func Precision(total, scale int) ValidatorFunc {
+	return func(value interface{}) error {
+		if value == nil {
+			return invalidType("Precision", "nil", value, nil)
+		}
+		r, ok := toDecimal(value)
+		if !ok {
+			return invalidType("Precision", "decimal", value, nil)
+		}
+		if n, _ := decimalPlaces(r); n > scale || integerDigits(r) > total-scale {
+			return invalid("Precision", "precision", value, Params{"total": total, "scale": scale})
 		}
 		return nil
 	}
@@ -115,14 +239,20 @@ func Each(validatorFunc ValidatorFunc) ValidatorFunc {
 		// Check if the value is a slice or array
 		v := reflect.ValueOf(value)
 		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
-			return errors.New("value must be a slice or array")
+			return invalidType("Each", "slice_or_array", value, nil)
 		}
 
 		// Iterate over each element and apply the validator function
 		for i := 0; i < v.Len(); i++ {
 			element := v.Index(i).Interface()
 			if err := validatorFunc(element); err != nil {
-				return fmt.Errorf("element at index %d: %v", i, err)
+				e := invalid(ruleName(validatorFunc), "each", element, Params{"index": i, "error": err.Error()})
+				e.Field = fmt.Sprintf("[%d]", i)
+				e.Err = err
+				if inner, ok := err.(*ValidationError); ok {
+					e.Code = inner.Code
+				}
+				return e
 			}
 		}
 
@@ -130,33 +260,74 @@ func Each(validatorFunc ValidatorFunc) ValidatorFunc {
 	}
 }
 
-// EachWithOptions applies a set of validation options to each element in a slice or array, returning the first error
+// EachWithOptions applies a set of validation options to each element in a slice or array.
+// Every failing element is reported, so ValidateAll can list them all while Validate keeps the first one.
+// Cross-field paths starting with "$." are resolved from the element, use EachWithOptionsContext to resolve them from the request body.
 func EachWithOptions(options []ValidationOption) ValidatorFunc {
 	return func(value interface{}) error {
-		if value == nil {
-			return fmt.Errorf("value must be a non-nil slice or array")
+		return eachWithOptions(context.Background(), options, value, true)
+	}
+}
+
+// EachWithOptionsContext is like EachWithOptions but passes the context of the run to context validators of the elements.
+// It follows the mode of the run, stopping at the first failing element unless the run collects every failure.
+func EachWithOptionsContext(options []ValidationOption) ContextValidatorFunc {
+	return func(ctx context.Context, value interface{}) error {
+		return eachWithOptions(ctx, options, value, collectsAll(ctx))
+	}
+}
+
+// eachWithOptions validates every element of a slice or array against options, returning the first failure
+// unless collectAll is set.
+func eachWithOptions(ctx context.Context, options []ValidationOption, value interface{}, collectAll bool) error {
+	if value == nil {
+		return invalidType("EachWithOptions", "nil_slice", value, nil)
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return invalidType("EachWithOptions", "slice_or_array_type", value, Params{"type": fmt.Sprintf("%T", value)})
+	}
+	var opts []RunOption
+	if collectAll {
+		opts = []RunOption{CollectAll()}
+	}
+	var errs ValidationErrors
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i).Interface()
+		nestedBody, ok := elem.(map[string]interface{})
+		if !ok {
+			// Structs and pointers to structs are validated like their JSON encoding
+			nestedBody = StructToMap(elem)
 		}
-		v := reflect.ValueOf(value)
-		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
-			return fmt.Errorf("value must be a slice or array, got %T", value)
+		if !ok && nestedBody == nil {
+			e := invalidType("object", "element_object", elem, Params{"index": i, "type": fmt.Sprintf("%T", elem)})
+			e.Field = fmt.Sprintf("[%d]", i)
+			if !collectAll {
+				return e
+			}
+			errs = append(errs, e)
+			continue
 		}
-		if v.Len() == 0 {
-			return nil
+		err := ValidateContext(ctx, nestedBody, options, opts...)
+		if err == nil {
+			continue
 		}
-		for i := 0; i < v.Len(); i++ {
-			elem := v.Index(i).Interface()
-			nestedBody, ok := elem.(map[string]interface{})
-			if !ok {
-				if reflect.TypeOf(elem).Kind() == reflect.Struct {
-					nestedBody = StructToMap(elem)
-				} else {
-					return fmt.Errorf("element at index %d must be an object, got %T", i, elem)
-				}
-			}
-			if err := Validate(nestedBody, options); err != nil {
-				return err
-			}
+		if e, ok := err.(*ValidationError); ok {
+			return withPath(fmt.Sprintf("[%d]", i), e)
 		}
-		return nil
+		nested, ok := err.(ValidationErrors)
+		if !ok {
+			return err
+		}
+		for _, e := range nested {
+			errs = append(errs, withPath(fmt.Sprintf("[%d]", i), e.(*ValidationError)))
+		}
+	}
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	if len(errs) > 0 {
+		return errs
 	}
+	return nil
 }
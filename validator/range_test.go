@@ -1,7 +1,11 @@
 package validator
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"math"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -23,7 +27,11 @@ func TestMinLength(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := minLength(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -44,7 +52,11 @@ func TestMaxLength(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := maxLength(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -62,13 +74,21 @@ func TestMaxValue(t *testing.T) {
 		{"equal to max", 100, nil},
 		{"greater than max (integer)", 101, errors.New("value must be less than or equal to 100")},
 		{"greater than max (float)", 100.1, errors.New("value must be less than or equal to 100")},
+		{"greater than max (uint)", uint64(101), errors.New("value must be less than or equal to 100")},
+		{"greater than max (json.Number)", json.Number("100.000000000000000001"), errors.New("value must be less than or equal to 100")},
+		{"equal to max (json.Number)", json.Number("1e2"), nil},
+		{"NaN", math.NaN(), errors.New("value must be a number")},
 		{"invalid type (string)", "100", errors.New("value must be a number")},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := maxValue(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -86,17 +106,86 @@ func TestMinValue(t *testing.T) {
 		{"equal to min", 10, nil},
 		{"less than min (integer)", 9, errors.New("value must be greater than or equal to 10")},
 		{"less than min (float)", 9.9, errors.New("value must be greater than or equal to 10")},
+		{"less than min (uint)", uint8(9), errors.New("value must be greater than or equal to 10")},
+		{"less than min (big.Int)", big.NewInt(-1), errors.New("value must be greater than or equal to 10")},
+		{"valid big.Int", new(big.Int).Lsh(big.NewInt(1), 70), nil},
 		{"invalid type (string)", "10", errors.New("value must be a number")},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := minValue(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
 
+func TestDecimalValidators(t *testing.T) {
+	tests := []struct {
+		name  string
+		fn    ValidatorFunc
+		input interface{}
+		error error
+	}{
+		{"greater than", GreaterThan(0), "0.01", nil},
+		{"not greater than", GreaterThan(0), json.Number("0"), errors.New("value must be greater than 0")},
+		{"greater than with float bound", GreaterThan(0.1), json.Number("0.1"), errors.New("value must be greater than 0.1")},
+		{"less than", LessThan(100), 99.99, nil},
+		{"not less than", LessThan(100), "100.00", errors.New("value must be less than 100")},
+		{"between", Between(0.01, 999.99), "999.99", nil},
+		{"below", Between(0.01, 999.99), "0.009", errors.New("value must be between 0.01 and 999.99")},
+		{"above with big.Int", Between(0.01, 999.99), big.NewInt(1000), errors.New("value must be between 0.01 and 999.99")},
+		{"decimal places", MaxDecimalPlaces(2), "12.50", nil},
+		{"decimal places with trailing zeros", MaxDecimalPlaces(2), "12.5000", nil},
+		{"decimal places of a float", MaxDecimalPlaces(2), 0.30000000000000004, errors.New("value must have at most 2 decimal places")},
+		{"too many decimal places", MaxDecimalPlaces(2), json.Number("1.005"), errors.New("value must have at most 2 decimal places")},
+		{"decimal places with exponent", MaxDecimalPlaces(0), json.Number("1.5e2"), nil},
+		{"multiple of", MultipleOf(0.05), "12.35", nil},
+		{"not a multiple of", MultipleOf(0.05), "12.36", errors.New("value must be a multiple of 0.05")},
+		{"negative multiple of", MultipleOf(0.25), -0.75, nil},
+		{"precision", Precision(5, 2), "-999.99", nil},
+		{"precision of a fraction", Precision(2, 2), "0.99", nil},
+		{"too many integer digits", Precision(5, 2), "1000", errors.New("value must have at most 5 digits, 2 of them after the decimal point")},
+		{"precision of an integer", Precision(5, 2), uint8(255), nil},
+		{"precision with too many places", Precision(5, 2), "1.001", errors.New("value must have at most 5 digits, 2 of them after the decimal point")},
+		{"not a decimal string", Between(0, 1), "0.5 ", errors.New("value must be a decimal number")},
+		{"exponent string", Between(0, 1), "5e-1", errors.New("value must be a decimal number")},
+		{"invalid json.Number", MaxDecimalPlaces(2), json.Number("1/2"), errors.New("value must be a decimal number")},
+		{"non-terminating rational", MaxDecimalPlaces(2), big.NewRat(1, 3), errors.New("value must be a decimal number")},
+		{"NaN", GreaterThan(0), math.NaN(), errors.New("value must be a decimal number")},
+		{"nil", LessThan(0), nil, errors.New("value is nil")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.fn(test.input)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
+		})
+	}
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		require.PanicsWithValue(t, "Invalid MultipleOf step: 0", func() { MultipleOf(0) })
+		require.PanicsWithValue(t, "Invalid GreaterThan bound: NaN", func() { GreaterThan(math.NaN()) })
+	})
+
+	t.Run("money field", func(t *testing.T) {
+		options := Object(Field("price").ToFixedDecimal(2).GreaterThan(0).Precision(6, 2).MultipleOf(0.05))
+		body := map[string]interface{}{"price": json.Number("12.5")}
+		require.NoError(t, Validate(body, options))
+		require.Equal(t, "12.50", body["price"])
+		require.EqualError(t, Validate(map[string]interface{}{"price": "12.999"}, options), "value must have at most 2 decimal places")
+		require.EqualError(t, Validate(map[string]interface{}{"price": 12345.6}, options), "value must have at most 6 digits, 2 of them after the decimal point")
+	})
+}
+
 func TestEach(t *testing.T) {
 	// Test with IsString validator
 	t.Run("Each element is a string", func(t *testing.T) {
@@ -254,6 +343,32 @@ func TestEachWithOptions(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("follows the mode of the run", func(t *testing.T) {
+		validated := 0
+		counted := func(ctx context.Context, value interface{}) error {
+			validated++
+			return IsString(value)
+		}
+		options := Object(Field("items").ValidateContext(EachWithOptionsContext(Object(Field("name").ValidateContext(counted)))))
+		body := map[string]interface{}{"items": []interface{}{
+			map[string]interface{}{"name": 1},
+			map[string]interface{}{"name": 2},
+		}}
+
+		err := Validate(body, options)
+		var e *ValidationError
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, "items[0].name", e.Field)
+		require.Equal(t, 1, validated)
+
+		validated = 0
+		err = ValidateAll(body, options)
+		var errs ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 2)
+		require.Equal(t, 2, validated)
+	})
 }
 
 var ProductVariantsValidationOptions = []ValidationOption{
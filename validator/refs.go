@@ -0,0 +1,129 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DefaultMaxDepth is the number of nested references a run follows unless WithMaxDepth sets another limit.
+const DefaultMaxDepth = 32
+
+// Registry holds named option sets that fields reference with Ref and ArrayOfRef, so schemas can be shared
+// and can reference themselves, e.g. a category whose children are categories. It is safe for concurrent use.
+type Registry struct {
+	mu          sync.RWMutex
+	definitions map[string][]ValidationOption
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{definitions: make(map[string][]ValidationOption)}
+}
+
+// Define registers the options built from fields under name, replacing any previous definition, and returns r.
+// References are resolved by name when validating, so a definition may reference itself or one defined later.
+func (r *Registry) Define(name string, fields ...FieldSpec) *Registry {
+	return r.DefineOptions(name, Object(fields...))
+}
+
+// DefineOptions is like Define with options built beforehand.
+func (r *Registry) DefineOptions(name string, options []ValidationOption) *Registry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.definitions[name] = options
+	return r
+}
+
+// Lookup returns the options defined under name.
+func (r *Registry) Lookup(name string) ([]ValidationOption, bool) {
+	if r == nil {
+		return nil, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	options, ok := r.definitions[name]
+	return options, ok
+}
+
+// Compile compiles the definition name into a Schema resolving references against r, see the package-level Compile.
+func (r *Registry) Compile(name string, opts ...RunOption) (*Schema, error) {
+	options, ok := r.Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("undefined reference %q", name)
+	}
+	return compile(options, name, append([]RunOption{WithRegistry(r)}, opts...))
+}
+
+// MustCompile is like Compile but panics if the definition is invalid.
+func (r *Registry) MustCompile(name string, opts ...RunOption) *Schema {
+	s, err := r.Compile(name, opts...)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid validation options: %s", err))
+	}
+	return s
+}
+
+// WithRegistry resolves the references of the run, including the ones of nested runs such as ArrayOfRef elements, against r.
+func WithRegistry(r *Registry) RunOption {
+	return func(v *validation) {
+		v.refs.registry = r
+	}
+}
+
+// WithMaxDepth limits the number of nested references a run follows, DefaultMaxDepth by default.
+// A value nested deeper is reported at its path instead of being validated, which bounds the work spent on recursive payloads.
+func WithMaxDepth(depth int) RunOption {
+	return func(v *validation) {
+		v.refs.maxDepth = depth
+	}
+}
+
+// refsKey is the context key carrying the refs of a run into nested runs such as EachRef elements.
+type refsKey struct{}
+
+// refs is the state of the references followed by a run.
+type refs struct {
+	registry *Registry
+	maxDepth int // Limit of depth, DefaultMaxDepth when zero
+	depth    int // Number of references followed to reach the value being validated
+}
+
+// limit returns the maximum depth of the run.
+func (r refs) limit() int {
+	if r.maxDepth > 0 {
+		return r.maxDepth
+	}
+	return DefaultMaxDepth
+}
+
+// follow returns the options defined under name and the refs of the values they validate, or the error reported
+// when the definition is missing or the maximum depth is reached.
+func (r refs) follow(name string) ([]ValidationOption, refs, error) {
+	options, ok := r.registry.Lookup(name)
+	if !ok {
+		return nil, r, Internal(fmt.Errorf("validator: undefined reference %q", name))
+	}
+	if r.depth >= r.limit() {
+		return nil, r, invalid("Ref", "max_depth", nil, Params{"max": r.limit()})
+	}
+	r.depth++
+	return options, r, nil
+}
+
+// EachRef validates every element of a slice or array against the definition name of the registry of the run,
+// like EachWithOptionsContext. See Registry.
+func EachRef(name string) ContextValidatorFunc {
+	return func(ctx context.Context, value interface{}) error {
+		r, _ := ctx.Value(refsKey{}).(refs)
+		options, r, err := r.follow(name)
+		if err != nil {
+			// An empty array nests no object, so it cannot be too deep
+			if v, e := elements("EachRef", value); e == nil && v.Len() == 0 && !isInternal(err) {
+				return nil
+			}
+			return err
+		}
+		return eachWithOptions(context.WithValue(ctx, refsKey{}, r), options, value, collectsAll(ctx))
+	}
+}
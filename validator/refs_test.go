@@ -0,0 +1,108 @@
+package validator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry().
+		Define("Category",
+			Field("name").Trim().String().MinLength(1),
+			Field("parent").Optional().Nullable().Ref("Category"),
+			Field("children").Optional().ArrayOfRef("Category"),
+		).
+		Define("Comment",
+			Field("author").Ref("User"),
+			Field("replies").Optional().ArrayOfRef("Comment"),
+		).
+		Define("User", Field("name").String())
+	category := func(name string, children ...interface{}) map[string]interface{} {
+		return map[string]interface{}{"name": name, "children": children}
+	}
+
+	t.Run("recursive definitions", func(t *testing.T) {
+		schema := registry.MustCompile("Category")
+		body := category(" root ", category("a", category("a1")), category(""))
+		err := schema.ValidateAll(body)
+		var errs ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 1)
+		require.Equal(t, "children[1].name", errs[0].(*ValidationError).Field)
+		require.Equal(t, "root", body["name"])
+
+		body["children"] = []interface{}{category("a", category("a1"))}
+		body["parent"] = map[string]interface{}{"name": "top", "parent": map[string]interface{}{}}
+		err = schema.Validate(body)
+		var ve *ValidationError
+		require.ErrorAs(t, err, &ve)
+		require.Equal(t, "parent.parent.name", ve.Field)
+	})
+
+	t.Run("definitions referencing each other", func(t *testing.T) {
+		body := map[string]interface{}{
+			"author":  map[string]interface{}{"name": "ann"},
+			"replies": []interface{}{map[string]interface{}{"author": map[string]interface{}{"name": 1}}},
+		}
+		err := ValidateContext(context.Background(), body, Object(Field("comment").Ref("Comment")), WithRegistry(registry))
+		require.EqualError(t, err, "comment is required")
+		err = ValidateContext(context.Background(), map[string]interface{}{"comment": body}, Object(Field("comment").Ref("Comment")), WithRegistry(registry))
+		var ve *ValidationError
+		require.ErrorAs(t, err, &ve)
+		require.Equal(t, "comment.replies[0].author.name", ve.Field)
+	})
+
+	t.Run("max depth", func(t *testing.T) {
+		deep := category("c")
+		for range 3 {
+			deep = category("c", deep)
+		}
+		schema := registry.MustCompile("Category", WithMaxDepth(2))
+		err := schema.Validate(deep)
+		var ve *ValidationError
+		require.ErrorAs(t, err, &ve)
+		require.Equal(t, "children[0].children[0].children", ve.Field)
+		require.Equal(t, "max_depth", ve.Key)
+		require.EqualError(t, err, "value is nested deeper than 2 levels")
+		require.NoError(t, schema.ValidateContext(context.Background(), deep, WithMaxDepth(3)))
+
+		parents := map[string]interface{}{"name": "c"}
+		for range DefaultMaxDepth + 1 {
+			parents = map[string]interface{}{"name": "c", "parent": parents}
+		}
+		err = registry.MustCompile("Category").Validate(parents)
+		require.ErrorAs(t, err, &ve)
+		require.Equal(t, "max_depth", ve.Key)
+		require.Equal(t, strings.Repeat("parent.", DefaultMaxDepth)+"parent", ve.Field)
+		require.Equal(t, "Ref", ve.Rule)
+	})
+
+	t.Run("compile", func(t *testing.T) {
+		_, err := Compile(Object(Field("parent").Ref("Category")))
+		require.EqualError(t, err, `parent: reference "Category" needs a registry, see WithRegistry`)
+
+		broken := NewRegistry().
+			Define("Node", Field("next").Ref("Node"), Field("link").Ref("Link")).
+			Define("Link", Field("node").Ref("Node"), Field("target").Ref("Missing"), Field("id"), Field("id"))
+		_, err = broken.Compile("Node")
+		require.EqualError(t, err, "#Link.target: undefined reference \"Missing\"\n#Link.id: duplicate key")
+
+		_, err = broken.Compile("Missing")
+		require.EqualError(t, err, `undefined reference "Missing"`)
+
+		option := Field("node").Object(Field("id")).Option()
+		option.Ref = "Node"
+		_, err = Compile([]ValidationOption{option}, WithRegistry(broken))
+		require.EqualError(t, err, "node: option sets both Nested and Ref")
+	})
+
+	t.Run("undefined at validation time", func(t *testing.T) {
+		err := Validate(map[string]interface{}{"items": []interface{}{}}, Object(Field("items").ArrayOfRef("Item")))
+		var internal *InternalError
+		require.ErrorAs(t, err, &internal)
+		require.EqualError(t, internal.Err, `validator: undefined reference "Item"`)
+	})
+}
@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+)
+
+// Sanitize validates a deep copy of body and returns it transformed, leaving body untouched.
+// Pass WithUnknownKeys(StripUnknownKeys) to only keep the declared keys in the output.
+func Sanitize(body map[string]interface{}, options []ValidationOption, opts ...RunOption) (map[string]interface{}, error) {
+	return SanitizeContext(context.Background(), body, options, opts...)
+}
+
+// SanitizeContext is like Sanitize but validates with ValidateContext.
+func SanitizeContext(ctx context.Context, body map[string]interface{}, options []ValidationOption, opts ...RunOption) (map[string]interface{}, error) {
+	output := deepCopy(body).(map[string]interface{})
+	if err := ValidateContext(ctx, output, options, opts...); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// deepCopy copies maps and slices recursively, returning other values such as structs and pointers as they are.
+func deepCopy(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			copied[k] = deepCopy(v)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(value))
+		for i, v := range value {
+			copied[i] = deepCopy(v)
+		}
+		return copied
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Slice:
+		if rv.IsNil() {
+			return value
+		}
+		copied := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			copyValue(copied.Index(i), rv.Index(i))
+		}
+		return copied.Interface()
+	case reflect.Map:
+		if rv.IsNil() {
+			return value
+		}
+		copied := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			copyValue(elem, iter.Value())
+			copied.SetMapIndex(iter.Key(), elem)
+		}
+		return copied.Interface()
+	default:
+		return value
+	}
+}
+
+// copyValue sets dst to a deep copy of src, both of the same type.
+func copyValue(dst, src reflect.Value) {
+	if src.Kind() == reflect.Interface && src.IsNil() {
+		return
+	}
+	dst.Set(reflect.ValueOf(deepCopy(src.Interface())).Convert(dst.Type()))
+}
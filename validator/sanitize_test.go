@@ -0,0 +1,86 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitize(t *testing.T) {
+	options := Object(
+		Field("email").Trim().ToLower().Email(),
+		Field("role").Optional().Default("user"),
+		Field("tags").Optional().Each(IsString),
+		Field("address").Object(
+			Field("city").Trim(),
+		),
+		Field("items").ArrayOf(
+			Field("sku").ToUpper(),
+		),
+	)
+	input := func() map[string]interface{} {
+		return map[string]interface{}{
+			"email":   " Alice@Example.com ",
+			"tags":    []string{"a"},
+			"address": map[string]interface{}{"city": " Paris ", "zip": "75001"},
+			"items":   []interface{}{map[string]interface{}{"sku": "ab"}},
+			"admin":   true,
+		}
+	}
+
+	t.Run("the input is left untouched", func(t *testing.T) {
+		body := input()
+		output, err := Sanitize(body, options)
+		require.NoError(t, err)
+		require.Equal(t, input(), body)
+		require.Equal(t, map[string]interface{}{
+			"email":   "alice@example.com",
+			"role":    "user",
+			"tags":    []string{"a"},
+			"address": map[string]interface{}{"city": "Paris", "zip": "75001"},
+			"items":   []interface{}{map[string]interface{}{"sku": "AB"}},
+			"admin":   true,
+		}, output)
+
+		output["tags"].([]string)[0] = "changed"
+		require.Equal(t, []string{"a"}, body["tags"])
+	})
+
+	t.Run("unknown keys can be stripped from the output", func(t *testing.T) {
+		body := input()
+		output, err := Sanitize(body, options, WithUnknownKeys(StripUnknownKeys))
+		require.NoError(t, err)
+		require.Equal(t, input(), body)
+		require.NotContains(t, output, "admin")
+		require.Equal(t, map[string]interface{}{"city": "Paris"}, output["address"])
+	})
+
+	t.Run("invalid input returns no output", func(t *testing.T) {
+		body := input()
+		body["email"] = "invalid"
+		output, err := Sanitize(body, options)
+		require.EqualError(t, err, "value is not a valid email address")
+		require.Nil(t, output)
+		require.Equal(t, "invalid", body["email"])
+	})
+}
+
+func TestDeepCopy(t *testing.T) {
+	type point struct{ X int }
+	value := map[string]interface{}{
+		"list":   []interface{}{map[string]interface{}{"a": 1}, nil},
+		"typed":  []map[string]interface{}{{"b": 2}},
+		"nested": map[string][]int{"c": {3}},
+		"struct": point{1},
+		"nil":    nil,
+	}
+	copied := deepCopy(value).(map[string]interface{})
+	require.Equal(t, value, copied)
+
+	copied["list"].([]interface{})[0].(map[string]interface{})["a"] = 0
+	copied["typed"].([]map[string]interface{})[0]["b"] = 0
+	copied["nested"].(map[string][]int)["c"][0] = 0
+	require.Equal(t, 1, value["list"].([]interface{})[0].(map[string]interface{})["a"])
+	require.Equal(t, 2, value["typed"].([]map[string]interface{})[0]["b"])
+	require.Equal(t, 3, value["nested"].(map[string][]int)["c"][0])
+}
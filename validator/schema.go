@@ -0,0 +1,176 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Schema is a set of validation options checked once by Compile and reused across requests.
+// It is safe for concurrent use.
+type Schema struct {
+	options []ValidationOption
+	opts    []RunOption
+	runs    sync.Pool // *validation reused across runs
+}
+
+// Compile checks the option tree and returns a Schema validating bodies against it with the given run options.
+// It reports every option without a key other than OneOf, duplicate keys of an object, duplicate branch names,
+// validators that don't set exactly one of Func, ContextFunc and CrossFunc, nil transformers, unknown UnknownKeys policies,
+// and Ref options naming no definition of the registry set by WithRegistry. The elements of ArrayOf and ArrayOfRef fields
+// are checked at paths such as "items[].sku", and every definition reached is checked once, at a path starting with its
+// name such as "#Category", so recursive definitions are safe. Validators built directly from EachWithOptions,
+// EachWithOptionsContext or EachRef hide their options from Compile and are only checked by validating.
+func Compile(options []ValidationOption, opts ...RunOption) (*Schema, error) {
+	return compile(options, "", opts)
+}
+
+// compile is Compile for the options of the definition name, or of no definition when name is empty.
+// The definition is checked at the root only, not again where it references itself.
+func compile(options []ValidationOption, name string, opts []RunOption) (*Schema, error) {
+	var settings validation
+	settings.apply(opts)
+	c := checker{registry: settings.refs.registry, checked: make(map[string]bool)}
+	if name != "" {
+		c.checked[name] = true
+	}
+	if err := c.check(options, ""); err != nil {
+		return nil, err
+	}
+	return &Schema{options: options, opts: opts}, nil
+}
+
+// MustCompile is like Compile but panics if the options are invalid.
+func MustCompile(options []ValidationOption, opts ...RunOption) *Schema {
+	s, err := Compile(options, opts...)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid validation options: %s", err))
+	}
+	return s
+}
+
+// Options returns the validation options of the schema.
+func (s *Schema) Options() []ValidationOption {
+	return s.options
+}
+
+// Validate checks the request body against the schema and returns the first error as a *ValidationError.
+func (s *Schema) Validate(body map[string]interface{}) error {
+	return s.ValidateContext(context.Background(), body)
+}
+
+// ValidateAll checks the request body against the schema and returns every failure as ValidationErrors.
+func (s *Schema) ValidateAll(body map[string]interface{}) error {
+	return s.ValidateContext(context.Background(), body, CollectAll())
+}
+
+// ValidateContext is like the package-level ValidateContext, applying opts after the run options of the schema.
+func (s *Schema) ValidateContext(ctx context.Context, body map[string]interface{}, opts ...RunOption) error {
+	v, ok := s.runs.Get().(*validation)
+	if !ok {
+		v = &validation{}
+	}
+	v.reset(ctx, body)
+	v.apply(s.opts)
+	v.apply(opts)
+	err := v.run(body, s.options)
+	// Drop the references to the body and errors before reusing v
+	*v = validation{}
+	s.runs.Put(v)
+	return err
+}
+
+// checker checks option trees for Compile.
+type checker struct {
+	registry *Registry       // Registry resolving Ref options, nil when the run has none
+	checked  map[string]bool // Definitions already checked
+}
+
+// check reports the problems of the options of the object at path.
+func (c *checker) check(options []ValidationOption, path string) error {
+	var errs []error
+	keys := make(map[string]bool, len(options))
+	for i, option := range options {
+		fieldPath := joinPath(path, option.Key)
+		if option.Key == "" {
+			fieldPath = joinPath(path, fmt.Sprintf("[%d]", i))
+			if option.Branches == nil {
+				errs = append(errs, fmt.Errorf("%s: option has no key", fieldPath))
+			}
+		} else if keys[option.Key] {
+			errs = append(errs, fmt.Errorf("%s: duplicate key", fieldPath))
+		}
+		keys[option.Key] = true
+
+		for j, validator := range option.Validators {
+			set := 0
+			for _, isSet := range []bool{validator.Func != nil, validator.ContextFunc != nil, validator.CrossFunc != nil} {
+				if isSet {
+					set++
+				}
+			}
+			if set != 1 {
+				errs = append(errs, fmt.Errorf("%s: validator %d must set exactly one of Func, ContextFunc and CrossFunc", fieldPath, j))
+			}
+			if err := c.check(validator.elements, fieldPath+"[]"); err != nil {
+				errs = append(errs, err)
+			}
+			if err := c.checkRef(validator.elementsRef, fieldPath+"[]"); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		for j, transformer := range option.Transformers {
+			if transformer == nil {
+				errs = append(errs, fmt.Errorf("%s: transformer %d is nil", fieldPath, j))
+			}
+		}
+		for j, transformer := range option.FallibleTransformers {
+			if transformer == nil {
+				errs = append(errs, fmt.Errorf("%s: fallible transformer %d is nil", fieldPath, j))
+			}
+		}
+		if option.UnknownKeys < InheritUnknownKeys || option.UnknownKeys > StripUnknownKeys {
+			errs = append(errs, fmt.Errorf("%s: unknown UnknownKeys policy %d", fieldPath, option.UnknownKeys))
+		}
+		if err := c.check(option.Nested, fieldPath); err != nil {
+			errs = append(errs, err)
+		}
+		if option.Ref != "" && option.Nested != nil {
+			errs = append(errs, fmt.Errorf("%s: option sets both Nested and Ref", fieldPath))
+		} else if err := c.checkRef(option.Ref, fieldPath); err != nil {
+			errs = append(errs, err)
+		}
+		names := make(map[string]bool, len(option.Branches))
+		for _, branch := range option.Branches {
+			if names[branch.Name] {
+				errs = append(errs, fmt.Errorf("%s: duplicate branch %q", fieldPath, branch.Name))
+			}
+			names[branch.Name] = true
+			if err := c.check(branch.Options, fmt.Sprintf("%s<%s>", fieldPath, branch.Name)); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkRef reports the problems of the reference name of the value at path, checking the definition it names
+// unless it was already checked.
+func (c *checker) checkRef(name, path string) error {
+	if name == "" {
+		return nil
+	}
+	if c.registry == nil {
+		return fmt.Errorf("%s: reference %q needs a registry, see WithRegistry", path, name)
+	}
+	options, ok := c.registry.Lookup(name)
+	if !ok {
+		return fmt.Errorf("%s: undefined reference %q", path, name)
+	}
+	if c.checked[name] {
+		return nil
+	}
+	c.checked[name] = true
+	return c.check(options, "#"+name)
+}
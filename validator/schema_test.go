@@ -0,0 +1,140 @@
+package validator
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompile(t *testing.T) {
+	t.Run("valid options", func(t *testing.T) {
+		schema, err := Compile(Object(
+			Field("name").Trim().MinLength(2),
+			Field("address").Object(Field("city").String()),
+		), Strict())
+		require.NoError(t, err)
+		require.Len(t, schema.Options(), 2)
+
+		require.NoError(t, schema.Validate(map[string]interface{}{"name": " Al ", "address": map[string]interface{}{"city": "Paris"}}))
+		require.EqualError(t, schema.Validate(map[string]interface{}{"name": "Al", "address": map[string]interface{}{"city": "Paris"}, "x": 1}), "x is not allowed")
+		require.EqualError(t, schema.ValidateAll(map[string]interface{}{"name": "A", "address": map[string]interface{}{}}), "value must be at least 2 characters long; city is required")
+		err = schema.ValidateContext(context.Background(), map[string]interface{}{"address": map[string]interface{}{"city": "Paris"}}, WithLocale("fr"))
+		require.EqualError(t, err, "name est obligatoire")
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := Compile([]ValidationOption{
+			{Key: "name", Validators: []Validator{{}}},
+			{Key: "name"},
+			{Transformers: []Transformer{nil}},
+			{Key: "address", UnknownKeys: 9, Nested: []ValidationOption{
+				{Key: "city", FallibleTransformers: []FallibleTransformer{nil}, Validators: []Validator{{Func: IsString, CrossFunc: EqualsField("x")}}},
+			}},
+		})
+		require.EqualError(t, err, "name: validator 0 must set exactly one of Func, ContextFunc and CrossFunc\n"+
+			"name: duplicate key\n"+
+			"[2]: option has no key\n"+
+			"[2]: transformer 0 is nil\n"+
+			"address: unknown UnknownKeys policy 9\n"+
+			"address.city: validator 0 must set exactly one of Func, ContextFunc and CrossFunc\n"+
+			"address.city: fallible transformer 0 is nil")
+
+		require.Panics(t, func() { MustCompile([]ValidationOption{{}}) })
+	})
+
+	t.Run("array elements", func(t *testing.T) {
+		_, err := Compile(Object(
+			Field("items").ArrayOf(
+				Field("sku"),
+				Field("sku"),
+				Field("tags").ArrayOf(Field("").String()),
+			),
+			Field("children").ArrayOfRef("Node"),
+		))
+		require.EqualError(t, err, "items[].sku: duplicate key\n"+
+			"items[].tags[][0]: option has no key\n"+
+			`children[]: reference "Node" needs a registry, see WithRegistry`)
+
+		registry := NewRegistry().Define("Node", Field("children").ArrayOfRef("Node"), Field("links").ArrayOfRef("Link"))
+		_, err = registry.Compile("Node")
+		require.EqualError(t, err, `links[]: undefined reference "Link"`)
+		registry.Define("Link", Field("target").Ref("Node"))
+		_, err = registry.Compile("Node")
+		require.NoError(t, err)
+	})
+
+	t.Run("concurrent use", func(t *testing.T) {
+		schema := MustCompile(Object(Field("n").Int().Min(1)))
+		done := make(chan error)
+		for i := range 8 {
+			go func() {
+				done <- schema.Validate(map[string]interface{}{"n": i % 2})
+			}()
+		}
+		failures := 0
+		for range 8 {
+			if err := <-done; err != nil {
+				require.EqualError(t, err, "value must be greater than or equal to 1")
+				failures++
+			}
+		}
+		require.Equal(t, 4, failures)
+	})
+}
+
+func benchmarkOptions() []ValidationOption {
+	return Object(
+		Field("id").UUID(),
+		Field("name").Trim().Alpha().MinLength(2),
+		Field("color").HexColor(),
+		Field("code").Alphanumeric(),
+		Field("age").Int().Min(18),
+	)
+}
+
+func benchmarkBody() map[string]interface{} {
+	return map[string]interface{}{"id": "123e4567-e89b-12d3-a456-426614174000", "name": "Alice", "color": "#ff0000", "code": "abc123", "age": 30}
+}
+
+func BenchmarkValidate(b *testing.B) {
+	options, body := benchmarkOptions(), benchmarkBody()
+	b.ReportAllocs()
+	for range b.N {
+		if err := Validate(body, options); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSchemaValidate(b *testing.B) {
+	schema, body := MustCompile(benchmarkOptions()), benchmarkBody()
+	b.ReportAllocs()
+	for range b.N {
+		if err := schema.Validate(body); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkIsUUID compares the precompiled pattern of IsUUID with compiling it on every call.
+func BenchmarkIsUUID(b *testing.B) {
+	const uuid = "123e4567-e89b-12d3-a456-426614174000"
+	b.Run("precompiled", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if err := IsUUID(uuid); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("per call", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if ok, _ := regexp.MatchString(uuidRegex.String(), uuid); !ok {
+				b.Fatal("no match")
+			}
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package validator
+
+import (
+	"context"
+	"slices"
+	"sort"
+)
+
+// UnknownKeys is the policy applied to keys of an object that none of its validation options declares.
+type UnknownKeys int
+
+const (
+	InheritUnknownKeys UnknownKeys = iota // Use the policy of the enclosing object, AllowUnknownKeys at the root by default
+	AllowUnknownKeys                      // Keep unknown keys
+	RejectUnknownKeys                     // Report every unknown key as a failure
+	StripUnknownKeys                      // Delete unknown keys from the body
+)
+
+// unknownKeysKey is the context key carrying the unknown keys policy into nested runs such as EachWithOptionsContext.
+type unknownKeysKey struct{}
+
+// WithUnknownKeys sets the unknown keys policy of the request body, inherited by nested objects and
+// EachWithOptionsContext elements that don't set their own with ValidationOption.UnknownKeys.
+func WithUnknownKeys(policy UnknownKeys) RunOption {
+	return func(v *validation) {
+		v.unknownKeys = policy
+	}
+}
+
+// Strict rejects unknown keys in the whole request body, see WithUnknownKeys.
+func Strict() RunOption {
+	return WithUnknownKeys(RejectUnknownKeys)
+}
+
+// inherit returns the policy to apply to an object declaring policy, nested in an object applying parent.
+func (policy UnknownKeys) inherit(parent UnknownKeys) UnknownKeys {
+	if policy == InheritUnknownKeys {
+		return parent
+	}
+	return policy
+}
+
+// validatorContext returns the context passed to validators of a field whose nested values apply policy.
+// The context also carries the references followed to reach the field when they differ from the ones of the run,
+// and the root of the request body once a context validator may start a nested run.
+func (v *validation) validatorContext(policy UnknownKeys, validators []Validator) context.Context {
+	if !v.rooted && slices.ContainsFunc(validators, func(validator Validator) bool { return validator.ContextFunc != nil }) {
+		v.ctx = context.WithValue(v.ctx, rootKey{}, v.root)
+		v.rooted = true
+	}
+	ctx := v.ctx
+	if policy != v.unknownKeys {
+		ctx = context.WithValue(ctx, unknownKeysKey{}, policy)
+	}
+	if v.refs.depth != v.baseDepth {
+		ctx = context.WithValue(ctx, refsKey{}, v.refs)
+	}
+	return ctx
+}
+
+// checkUnknownKeys applies policy to the keys of body that none of the options declares, in sorted order.
+func (v *validation) checkUnknownKeys(body map[string]interface{}, options []ValidationOption, path string, policy UnknownKeys) bool {
+	if policy != RejectUnknownKeys && policy != StripUnknownKeys {
+		return true
+	}
+	declared := make(map[string]bool, len(options))
+	for _, option := range options {
+		declared[option.Key] = true
+	}
+	var unknown []string
+	for key := range body {
+		if !declared[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+
+	for _, key := range unknown {
+		if policy == StripUnknownKeys {
+			delete(body, key)
+			continue
+		}
+		e := newError("unknown", CodeUnknownKey, "unknown_key", body[key], Params{"field": key})
+		e.Field = joinPath(path, key)
+		if !v.fail(e) {
+			return false
+		}
+	}
+	return true
+}
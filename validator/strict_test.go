@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnknownKeys(t *testing.T) {
+	options := Object(
+		Field("name").String(),
+		Field("address").Object(
+			Field("city").String(),
+		),
+		Field("items").Optional().ArrayOf(
+			Field("sku").String(),
+		),
+	)
+	body := func() map[string]interface{} {
+		return map[string]interface{}{
+			"name":    "Alice",
+			"role":    "admin",
+			"address": map[string]interface{}{"city": "Paris", "zip": "75001"},
+			"items":   []interface{}{map[string]interface{}{"sku": "a"}, map[string]interface{}{"sku": "b", "price": 0}},
+			"admin":   true,
+		}
+	}
+
+	t.Run("unknown keys are allowed by default", func(t *testing.T) {
+		b := body()
+		require.NoError(t, Validate(b, options))
+		require.Equal(t, "admin", b["role"])
+	})
+
+	t.Run("strict mode reports the path of every unknown key", func(t *testing.T) {
+		err := ValidateContext(context.Background(), body(), options, Strict(), CollectAll())
+		require.EqualError(t, err, "zip is not allowed; price is not allowed; admin is not allowed; role is not allowed")
+
+		var errs ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		var paths []string
+		for _, e := range errs {
+			ve := e.(*ValidationError)
+			require.Equal(t, CodeUnknownKey, ve.Code)
+			paths = append(paths, ve.Field)
+		}
+		require.Equal(t, []string{"address.zip", "items[1].price", "admin", "role"}, paths)
+	})
+
+	t.Run("first error mode stops at the first unknown key", func(t *testing.T) {
+		err := ValidateContext(context.Background(), body(), options, Strict())
+		var e *ValidationError
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, "address.zip", e.Field)
+	})
+
+	t.Run("strip mode deletes unknown keys", func(t *testing.T) {
+		b := body()
+		require.NoError(t, ValidateContext(context.Background(), b, options, WithUnknownKeys(StripUnknownKeys)))
+		require.Equal(t, map[string]interface{}{
+			"name":    "Alice",
+			"address": map[string]interface{}{"city": "Paris"},
+			"items":   []interface{}{map[string]interface{}{"sku": "a"}, map[string]interface{}{"sku": "b"}},
+		}, b)
+	})
+
+	t.Run("nested objects override the inherited policy", func(t *testing.T) {
+		options := Object(
+			Field("name").String(),
+			Field("address").Strict().Object(
+				Field("city").String(),
+			),
+			Field("items").Optional().StripUnknown().ArrayOf(
+				Field("sku").String(),
+			),
+		)
+		b := body()
+		err := ValidateAll(b, options)
+		require.EqualError(t, err, "zip is not allowed")
+		require.Equal(t, "admin", b["role"])
+		require.Equal(t, map[string]interface{}{"sku": "b"}, b["items"].([]interface{})[1])
+
+		b = body()
+		err = ValidateContext(context.Background(), b, options, WithUnknownKeys(StripUnknownKeys), CollectAll())
+		require.EqualError(t, err, "zip is not allowed")
+		require.NotContains(t, b, "role")
+	})
+
+	t.Run("messages are localized", func(t *testing.T) {
+		err := ValidateContext(context.Background(), map[string]interface{}{"name": "Alice", "address": map[string]interface{}{"city": "Paris"}, "x": 1}, options, Strict(), WithLocale("fr"))
+		require.EqualError(t, err, "x n'est pas autorisé")
+	})
+}
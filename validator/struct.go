@@ -0,0 +1,411 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// tagValidators maps validate tag tokens without parameters onto validators.
+var tagValidators = map[string]ValidatorFunc{
+	"notempty":    IsNotEmpty,
+	"alphanum":    IsAlphanumeric,
+	"email":       IsEmail,
+	"string":      IsString,
+	"number":      IsNumber,
+	"int":         IsInt,
+	"float":       IsFloat,
+	"bool":        IsBool,
+	"url":         IsURL,
+	"uuid":        IsUUID,
+	"date":        IsDate,
+	"time":        IsTime,
+	"creditcard":  IsCreditCard,
+	"hexcolor":    IsHexColor,
+	"json":        IsJSON,
+	"ip":          IsIP,
+	"alpha":       IsAlpha,
+	"arabic":      IsArabic,
+	"alphaarabic": IsAlphaArabic,
+	"base64":      IsBase64,
+	"base64image": IsBase64Image,
+}
+
+// tagParamValidators maps validate tag tokens of the form token=param onto validator factories.
+// The factories receive the dereferenced type of the field so parameters can be interpreted accordingly.
+var tagParamValidators = map[string]func(param string, t reflect.Type) (ValidatorFunc, error){
+	"min": func(param string, t reflect.Type) (ValidatorFunc, error) {
+		if isNumericKind(t.Kind()) {
+			n, err := strconv.ParseFloat(param, 64)
+			return Min(n), err
+		}
+		n, err := strconv.Atoi(param)
+		return MinLength(n), err
+	},
+	"max": func(param string, t reflect.Type) (ValidatorFunc, error) {
+		if isNumericKind(t.Kind()) {
+			n, err := strconv.ParseFloat(param, 64)
+			return Max(n), err
+		}
+		n, err := strconv.Atoi(param)
+		return MaxLength(n), err
+	},
+	"minlen": func(param string, t reflect.Type) (ValidatorFunc, error) {
+		n, err := strconv.Atoi(param)
+		return MinLength(n), err
+	},
+	"maxlen": func(param string, t reflect.Type) (ValidatorFunc, error) {
+		n, err := strconv.Atoi(param)
+		return MaxLength(n), err
+	},
+	"len": func(param string, t reflect.Type) (ValidatorFunc, error) {
+		n, err := strconv.Atoi(param)
+		return Length(n, n), err
+	},
+	"oneof": func(param string, t reflect.Type) (ValidatorFunc, error) {
+		values, err := tagValues(param, t)
+		return IsIn(values...), err
+	},
+	"notoneof": func(param string, t reflect.Type) (ValidatorFunc, error) {
+		values, err := tagValues(param, t)
+		return IsNotIn(values...), err
+	},
+	"regex": func(param string, t reflect.Type) (ValidatorFunc, error) {
+		return Regex(param), nil
+	},
+	"gt": func(param string, t reflect.Type) (ValidatorFunc, error) {
+		n, err := strconv.ParseFloat(param, 64)
+		return GreaterThan(n), err
+	},
+	"lt": func(param string, t reflect.Type) (ValidatorFunc, error) {
+		n, err := strconv.ParseFloat(param, 64)
+		return LessThan(n), err
+	},
+	"decimals": func(param string, t reflect.Type) (ValidatorFunc, error) {
+		n, err := strconv.Atoi(param)
+		return MaxDecimalPlaces(n), err
+	},
+	"multipleof": func(param string, t reflect.Type) (ValidatorFunc, error) {
+		n, err := strconv.ParseFloat(param, 64)
+		return MultipleOf(n), err
+	},
+}
+
+// tagTransformers maps validate tag tokens onto transformers, which run before the validators.
+var tagTransformers = map[string]func(param string) (Transformer, error){
+	"trim":  func(string) (Transformer, error) { return Trim, nil },
+	"lower": func(string) (Transformer, error) { return ToLower, nil },
+	"upper": func(string) (Transformer, error) { return ToUpper, nil },
+	"title": func(string) (Transformer, error) { return ToTitleCase, nil },
+	"truncate": func(param string) (Transformer, error) {
+		n, err := strconv.Atoi(param)
+		return Truncate(n), err
+	},
+}
+
+// isNumericKind checks if a kind is an integer or float kind.
+func isNumericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// tagValues parses the space-separated values of a oneof tag into the field's type.
+func tagValues(param string, t reflect.Type) ([]interface{}, error) {
+	var values []interface{}
+	for _, s := range strings.Fields(param) {
+		var value interface{}
+		switch {
+		case t.Kind() == reflect.String:
+			value = s
+		case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+			n, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			value = n
+		case t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uint64:
+			n, err := strconv.ParseUint(s, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			value = n
+		case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+			n, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return nil, err
+			}
+			value = n
+		default:
+			return nil, fmt.Errorf("oneof is not supported on %s", t)
+		}
+		values = append(values, reflect.ValueOf(value).Convert(t).Interface())
+	}
+	return values, nil
+}
+
+// structField holds the parsed validate tag of a struct field.
+type structField struct {
+	index        []int // Index sequence of the field, through embedded structs
+	name         string
+	required     bool
+	omitEmpty    bool
+	transformers []Transformer
+	validators   []Validator
+}
+
+// structType holds the parsed fields of a struct type, or the error of its first invalid validate tag.
+type structType struct {
+	fields []structField
+	err    error
+}
+
+// structCache caches the parsed fields of each struct type.
+var structCache sync.Map // map[reflect.Type]structType
+
+// structFields returns the parsed fields of a struct type, parsing their tags on first use.
+// Fields are resolved like StructToMap, so the fields promoted from embedded structs, including unexported ones, are validated too.
+// An invalid tag is reported, and cached, as an error naming the field.
+func structFields(t reflect.Type) ([]structField, error) {
+	if cached, ok := structCache.Load(t); ok {
+		st := cached.(structType)
+		return st.fields, st.err
+	}
+	var st structType
+	for _, jf := range jsonFields(t) {
+		f := t.FieldByIndex(jf.index)
+		field := structField{index: jf.index, name: jf.name}
+		if err := parseTag(&field, f); err != nil {
+			st = structType{err: fmt.Errorf("invalid validate tag on field %s.%s: %w", t, f.Name, err)}
+			break
+		}
+		st.fields = append(st.fields, field)
+	}
+	cached, _ := structCache.LoadOrStore(t, st)
+	st = cached.(structType)
+	return st.fields, st.err
+}
+
+// parseTag parses the validate tag of a struct field, e.g. `validate:"required,trim,email,min=6"`.
+func parseTag(field *structField, f reflect.StructField) error {
+	tag := f.Tag.Get("validate")
+	if tag == "" || tag == "-" {
+		return nil
+	}
+	t := f.Type
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	for _, token := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(token), "=")
+		var err error
+		switch {
+		case name == "required":
+			field.required = true
+		case name == "omitempty":
+			field.omitEmpty = true
+		case tagValidators[name] != nil:
+			field.validators = append(field.validators, CreateValidator(tagValidators[name], ""))
+		case tagParamValidators[name] != nil:
+			var fn ValidatorFunc
+			fn, err = tagValidator(tagParamValidators[name], param, t)
+			field.validators = append(field.validators, CreateValidator(fn, ""))
+		case tagTransformers[name] != nil:
+			var transformer Transformer
+			transformer, err = tagTransformers[name](param)
+			field.transformers = append(field.transformers, transformer)
+		default:
+			err = fmt.Errorf("unknown rule %q", name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tagValidator builds the validator of a token=param tag, reporting the panic of a validator rejecting its parameter,
+// such as Regex with an invalid pattern, as an error.
+func tagValidator(factory func(param string, t reflect.Type) (ValidatorFunc, error), param string, t reflect.Type) (fn ValidatorFunc, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			fn, err = nil, fmt.Errorf("%v", r)
+		}
+	}()
+	return factory(param, t)
+}
+
+// ValidateStruct validates a struct, or a pointer to a struct, against its `validate` tags and returns the first error.
+// Tag tokens map onto the built-in validators and transformers, e.g. `validate:"required,trim,lower,email,max=100"`.
+// Transformed values are written back when v is a pointer. Nested structs, pointers, slices and maps are validated recursively,
+// and field paths use the json tag names. Fields are resolved like StructToMap, so the fields promoted from embedded structs
+// are validated at the path of their name, and a struct reached again through a pointer cycle is skipped.
+// An invalid tag is reported as an *InternalError.
+func ValidateStruct(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return fmt.Errorf("value must be a non-nil struct, got %T", v)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("value must be a struct, got %T", v)
+	}
+	s := &validation{ctx: context.Background()}
+	s.structValue(rv, "")
+	if s.err != nil {
+		return s.err
+	}
+	if len(s.errs) == 0 {
+		return nil
+	}
+	return s.errs[0]
+}
+
+// structValue validates the fields of a struct and reports whether validation should go on.
+// A struct already being validated higher up, reached again through a pointer cycle, is skipped.
+func (v *validation) structValue(rv reflect.Value, path string) bool {
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return v.abort(Internal(err))
+	}
+	if rv.CanAddr() {
+		key := visit{ptr: rv.Addr().Pointer(), typ: rv.Type()}
+		if v.visiting[key] {
+			return true
+		}
+		if v.visiting == nil {
+			v.visiting = make(map[visit]bool)
+		}
+		v.visiting[key] = true
+		defer delete(v.visiting, key)
+	}
+	for _, field := range fields {
+		fv, ok := fieldByIndex(rv, field.index)
+		if !ok {
+			// A field promoted through a nil embedded pointer is empty
+			fv = reflect.Zero(rv.Type().FieldByIndex(field.index).Type)
+		}
+		if !v.structField(fv, field, joinPath(path, field.name)) {
+			return false
+		}
+	}
+	return true
+}
+
+// visit identifies a struct being validated by ValidateStruct.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// structField validates a single struct field and reports whether validation should go on.
+func (v *validation) structField(fv reflect.Value, field structField, path string) bool {
+	// Rules apply to the value a pointer points to, nil pointers count as empty
+	target := fv
+	for target.Kind() == reflect.Pointer && !target.IsNil() {
+		target = target.Elem()
+	}
+
+	if len(field.transformers) > 0 && !target.IsZero() {
+		// Structs passed by value are transformed on a copy so validators still see the transformed value
+		if !target.CanSet() {
+			writable := reflect.New(target.Type()).Elem()
+			writable.Set(target)
+			target = writable
+		}
+		value := target.Interface()
+		for _, transformer := range field.transformers {
+			value = transformer(value)
+		}
+		setTransformed(target, value)
+	}
+
+	if target.IsZero() {
+		if field.required {
+			e := newError("required", CodeRequired, "required", nil, Params{"field": field.name})
+			e.Field = path
+			return v.fail(e)
+		}
+		if field.omitEmpty || target.Kind() == reflect.Pointer || target.Kind() == reflect.Interface {
+			return true
+		}
+	}
+
+	value := target.Interface()
+	for _, validator := range field.validators {
+		if err := validator.Func(value); err != nil {
+			return v.failValidator(path, value, validator, err)
+		}
+	}
+
+	return v.nestedValue(target, path)
+}
+
+// nestedValue recurses into structs, pointers, slices, arrays and maps and reports whether validation should go on.
+func (v *validation) nestedValue(rv reflect.Value, path string) bool {
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return true
+		}
+		return v.nestedValue(rv.Elem(), path)
+	case reflect.Struct:
+		return v.structValue(rv, path)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if !v.nestedValue(rv.Index(i), fmt.Sprintf("%s[%d]", path, i)) {
+				return false
+			}
+		}
+	case reflect.Map:
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			// Map values are not addressable, so they are validated on a copy
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			elem.Set(rv.MapIndex(key))
+			if !v.nestedValue(elem, joinPath(path, fmt.Sprint(key.Interface()))) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// setTransformed writes a transformed value back into a struct field when possible.
+func setTransformed(target reflect.Value, value interface{}) {
+	if !target.CanSet() || value == nil {
+		return
+	}
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.Type().AssignableTo(target.Type()):
+		target.Set(rv)
+	case rv.Type().ConvertibleTo(target.Type()) && rv.Kind() == target.Kind() && rv.Kind() != reflect.Slice:
+		target.Set(rv.Convert(target.Type()))
+	case rv.Kind() == reflect.Slice && target.Kind() == reflect.Slice:
+		// Transformers return []any for slices, convert them back element by element
+		result := reflect.MakeSlice(target.Type(), rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			elem := reflect.ValueOf(rv.Index(i).Interface())
+			if !elem.IsValid() || !elem.Type().ConvertibleTo(target.Type().Elem()) {
+				return
+			}
+			result.Index(i).Set(elem.Convert(target.Type().Elem()))
+		}
+		target.Set(result)
+	}
+}
@@ -0,0 +1,186 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testAddress struct {
+	Street string `json:"street" validate:"required,trim"`
+	Zip    string `json:"zip" validate:"omitempty,len=5"`
+}
+
+type AuditFields struct {
+	CreatedBy string `json:"created_by" validate:"required"`
+}
+
+type testItem struct {
+	SKU      string `json:"sku" validate:"required,alphanum"`
+	Quantity int    `json:"quantity" validate:"min=1,max=100"`
+	Price    string `json:"price" validate:"omitempty,gt=0,decimals=2,multipleof=0.05"`
+}
+
+type testUser struct {
+	AuditFields
+	Email    string       `json:"email" validate:"required,trim,lower,email"`
+	Password string       `json:"password" validate:"required,min=6"`
+	Role     string       `json:"role" validate:"oneof=admin user"`
+	Age      *int         `json:"age" validate:"min=18"`
+	Tags     []string     `json:"tags" validate:"upper,maxlen=3"`
+	Address  *testAddress `json:"address"`
+	Items    []testItem   `json:"items"`
+	Ignored  string       `json:"-" validate:"required"`
+	internal string
+}
+
+func TestValidateStruct(t *testing.T) {
+	age := func(n int) *int { return &n }
+	valid := func() testUser {
+		return testUser{
+			AuditFields: AuditFields{CreatedBy: "admin"},
+			Email:       "user@example.com",
+			Password:    "password123",
+			Role:        "user",
+			Address:     &testAddress{Street: "Main St"},
+			Items:       []testItem{{SKU: "A1", Quantity: 2}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(u *testUser)
+		field  string
+		error  error
+	}{
+		{"valid struct", func(u *testUser) {}, "", nil},
+		{"missing required field", func(u *testUser) { u.Password = "" }, "password", errors.New("password is required")},
+		{"invalid email", func(u *testUser) { u.Email = "invalid" }, "email", errors.New("value is not a valid email address")},
+		{"string too short", func(u *testUser) { u.Password = "pass" }, "password", errors.New("value must be at least 6 characters long")},
+		{"not one of", func(u *testUser) { u.Role = "root" }, "role", errors.New("value must be one of [admin user]")},
+		{"nil pointer is skipped", func(u *testUser) { u.Age = nil }, "", nil},
+		{"pointer value", func(u *testUser) { u.Age = age(16) }, "age", errors.New("value must be greater than or equal to 18")},
+		{"slice too long", func(u *testUser) { u.Tags = []string{"a", "b", "c", "d"} }, "tags", errors.New("value must have at most 3 elements")},
+		{"nested struct", func(u *testUser) { u.Address.Street = "  " }, "address.street", errors.New("street is required")},
+		{"omitempty", func(u *testUser) { u.Address.Zip = "123" }, "address.zip", errors.New("value must be between 5 and 5 characters long")},
+		{"slice of structs", func(u *testUser) { u.Items = append(u.Items, testItem{SKU: "B2"}) }, "items[1].quantity", errors.New("value must be greater than or equal to 1")},
+		{"decimal price", func(u *testUser) { u.Items[0].Price = "9.95" }, "", nil},
+		{"price with too many decimals", func(u *testUser) { u.Items[0].Price = "9.999" }, "items[0].price", errors.New("value must have at most 2 decimal places")},
+		{"price not a multiple", func(u *testUser) { u.Items[0].Price = "9.99" }, "items[0].price", errors.New("value must be a multiple of 0.05")},
+		{"embedded struct", func(u *testUser) { u.CreatedBy = "" }, "created_by", errors.New("created_by is required")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			user := valid()
+			test.modify(&user)
+			err := ValidateStruct(&user)
+			if test.error == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, test.error.Error())
+			var ve *ValidationError
+			require.ErrorAs(t, err, &ve)
+			require.Equal(t, test.field, ve.Field)
+		})
+	}
+
+	t.Run("transformers write back through pointers", func(t *testing.T) {
+		user := valid()
+		user.Email = "  USER@Example.com "
+		user.Tags = []string{"a", "b"}
+		require.NoError(t, ValidateStruct(&user))
+		require.Equal(t, "user@example.com", user.Email)
+		require.Equal(t, []string{"A", "B"}, user.Tags)
+	})
+
+	t.Run("structs passed by value are validated", func(t *testing.T) {
+		user := valid()
+		user.Email = " USER@example.com"
+		require.NoError(t, ValidateStruct(user))
+		require.Equal(t, " USER@example.com", user.Email)
+
+		user.Address.Street = "   "
+		require.EqualError(t, ValidateStruct(user), "street is required")
+	})
+
+	t.Run("non-struct input", func(t *testing.T) {
+		require.EqualError(t, ValidateStruct("text"), "value must be a struct, got string")
+		require.EqualError(t, ValidateStruct((*testUser)(nil)), "value must be a non-nil struct, got *validator.testUser")
+	})
+
+	t.Run("invalid tags are internal errors", func(t *testing.T) {
+		type unknown struct {
+			Name string `validate:"required,unknown"`
+		}
+		type badPattern struct {
+			Code string `validate:"regex=[a-"`
+		}
+		tests := []struct {
+			name  string
+			input interface{}
+			error string
+		}{
+			{"unknown rule", unknown{Name: "a"}, `invalid validate tag on field validator.unknown.Name: unknown rule "unknown"`},
+			{"nested", struct{ Inner []unknown }{Inner: []unknown{{}}}, `invalid validate tag on field validator.unknown.Name: unknown rule "unknown"`},
+			{"invalid parameter", &badPattern{}, "invalid validate tag on field validator.badPattern.Code: Invalid regex pattern: error parsing regexp: missing closing ]: `[a-`"},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				// The error is cached with the parsed fields, so it is reported again on the next call
+				for range 2 {
+					err := ValidateStruct(test.input)
+					var internal *InternalError
+					require.ErrorAs(t, err, &internal)
+					require.EqualError(t, internal.Err, test.error)
+				}
+			})
+		}
+	})
+
+	t.Run("promoted fields", func(t *testing.T) {
+		type inner struct {
+			A int `json:"a" validate:"min=1"`
+		}
+		type outer struct {
+			inner
+			*AuditFields
+		}
+		err := ValidateStruct(outer{inner: inner{A: 0}, AuditFields: &AuditFields{CreatedBy: "admin"}})
+		var ve *ValidationError
+		require.ErrorAs(t, err, &ve)
+		require.Equal(t, "a", ve.Field)
+
+		// Fields promoted through a nil embedded pointer are empty
+		err = ValidateStruct(outer{inner: inner{A: 1}})
+		require.EqualError(t, err, "created_by is required")
+	})
+
+	t.Run("cycles are cut", func(t *testing.T) {
+		type node struct {
+			Name     string  `json:"name" validate:"required"`
+			Next     *node   `json:"next"`
+			Children []node  `json:"children"`
+			Links    []*node `json:"links"`
+		}
+		ring := &node{Name: "a", Next: &node{Name: "b"}}
+		ring.Next.Next = ring
+		ring.Links = []*node{ring, ring.Next}
+		ring.Children = []node{{Name: "c"}}
+		ring.Children[0].Children = ring.Children
+		done := make(chan error, 1)
+		go func() { done <- ValidateStruct(ring) }()
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("ValidateStruct did not return")
+		}
+
+		ring.Next.Name = ""
+		require.EqualError(t, ValidateStruct(ring), "name is required")
+	})
+}
@@ -0,0 +1,343 @@
+package validator
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// StructToMap converts a struct, or a pointer to a struct, to a map[string]interface{} for validation.
+// It follows the field rules of encoding/json: json tag names, "-", omitempty and the string option,
+// fields promoted from embedded structs, and json.Marshaler and encoding.TextMarshaler values.
+// Nested structs, pointers, slices and maps are converted recursively, while other values keep their Go type.
+// It returns nil when obj is not a struct or is a nil pointer, and pointer cycles are cut with nil.
+func StructToMap(obj interface{}) map[string]interface{} {
+	rv := reflect.ValueOf(obj)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	m, _ := mapValue(reflect.ValueOf(obj), map[uintptr]bool{}).(map[string]interface{})
+	return m
+}
+
+// jsonField describes how encoding/json encodes a struct field.
+type jsonField struct {
+	name      string
+	index     []int // Index sequence of the field, through embedded structs
+	tagged    bool  // Whether the name comes from the json tag
+	omitEmpty bool
+	quoted    bool // Whether the field has the string option
+}
+
+// jsonFieldCache caches the encoded fields of each struct type.
+var jsonFieldCache sync.Map // map[reflect.Type][]jsonField
+
+// jsonFields returns the fields encoding/json encodes for a struct type, in the order of the struct.
+// Like encoding/json, a field promoted from an embedded struct is hidden by a shallower field of the same name,
+// and fields of the same name at the same depth are all dropped unless exactly one of them is tagged.
+func jsonFields(t reflect.Type) []jsonField {
+	if cached, ok := jsonFieldCache.Load(t); ok {
+		return cached.([]jsonField)
+	}
+
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+	var candidates []jsonField
+	visited := map[reflect.Type]bool{}
+	next := []embedded{{typ: t}}
+	for len(next) > 0 {
+		current := next
+		next = nil
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+			visited[e.typ] = true
+			for i := range e.typ.NumField() {
+				f := e.typ.Field(i)
+				ft := f.Type
+				if ft.Name() == "" && ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if f.Anonymous {
+					// Exported fields of unexported embedded structs are still promoted
+					if !f.IsExported() && ft.Kind() != reflect.Struct {
+						continue
+					}
+				} else if !f.IsExported() {
+					continue
+				}
+				tag := f.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(tag, ",")
+				index := append(append([]int(nil), e.index...), i)
+				if name == "" && f.Anonymous && ft.Kind() == reflect.Struct {
+					next = append(next, embedded{typ: ft, index: index})
+					continue
+				}
+				field := jsonField{name: name, index: index, tagged: name != ""}
+				if name == "" {
+					field.name = f.Name
+				}
+				for _, opt := range strings.Split(opts, ",") {
+					if opt == "omitempty" {
+						field.omitEmpty = true
+					}
+				}
+				field.quoted = quotedOption(opts, f.Type)
+				candidates = append(candidates, field)
+			}
+		}
+	}
+
+	// Keep the dominant field of each name
+	byName := map[string][]jsonField{}
+	for _, field := range candidates {
+		byName[field.name] = append(byName[field.name], field)
+	}
+	var fields []jsonField
+	for _, named := range byName {
+		if field, ok := dominantField(named); ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		a, b := fields[i].index, fields[j].index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+
+	cached, _ := jsonFieldCache.LoadOrStore(t, fields)
+	return cached.([]jsonField)
+}
+
+// dominantField returns the field encoding/json keeps among fields of the same name.
+func dominantField(fields []jsonField) (jsonField, bool) {
+	depth := len(fields[0].index)
+	for _, field := range fields {
+		depth = min(depth, len(field.index))
+	}
+	var shallowest, tagged []jsonField
+	for _, field := range fields {
+		if len(field.index) == depth {
+			shallowest = append(shallowest, field)
+			if field.tagged {
+				tagged = append(tagged, field)
+			}
+		}
+	}
+	if len(shallowest) == 1 {
+		return shallowest[0], true
+	}
+	if len(tagged) == 1 {
+		return tagged[0], true
+	}
+	return jsonField{}, false
+}
+
+// mapValue converts a value the way encoding/json followed by decoding into an interface{} would,
+// except that scalars keep their Go type. seen holds the pointers being converted to detect cycles.
+func mapValue(rv reflect.Value, seen map[uintptr]bool) interface{} {
+	if !rv.IsValid() {
+		return nil
+	}
+	if (rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface) && rv.IsNil() {
+		return nil
+	}
+	if value, ok := marshalValue(rv); ok {
+		return value
+	}
+
+	switch rv.Kind() {
+	case reflect.Interface:
+		return mapValue(rv.Elem(), seen)
+	case reflect.Pointer:
+		ptr := rv.Pointer()
+		if seen[ptr] {
+			return nil
+		}
+		seen[ptr] = true
+		defer delete(seen, ptr)
+		return mapValue(rv.Elem(), seen)
+	case reflect.Struct:
+		result := make(map[string]interface{})
+		for _, field := range jsonFields(rv.Type()) {
+			fv, ok := fieldByIndex(rv, field.index)
+			if !ok || (field.omitEmpty && isEmptyValue(fv)) {
+				continue
+			}
+			if field.quoted {
+				result[field.name] = quotedValue(fv)
+				continue
+			}
+			result[field.name] = mapValue(fv, seen)
+		}
+		return result
+	case reflect.Map:
+		if rv.IsNil() {
+			return nil
+		}
+		result := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			result[mapKey(iter.Key())] = mapValue(iter.Value(), seen)
+		}
+		return result
+	case reflect.Slice:
+		if rv.IsNil() {
+			return nil
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			// Like encoding/json, byte slices are base64 strings
+			return base64.StdEncoding.EncodeToString(rv.Bytes())
+		}
+		fallthrough
+	case reflect.Array:
+		result := make([]interface{}, rv.Len())
+		for i := range rv.Len() {
+			result[i] = mapValue(rv.Index(i), seen)
+		}
+		return result
+	default:
+		return rv.Interface()
+	}
+}
+
+// marshalValue converts values implementing json.Marshaler or encoding.TextMarshaler.
+func marshalValue(rv reflect.Value) (interface{}, bool) {
+	t := rv.Type()
+	if !t.Implements(jsonMarshalerType) && !t.Implements(textMarshalerType) {
+		if !rv.CanAddr() || (!reflect.PointerTo(t).Implements(jsonMarshalerType) && !reflect.PointerTo(t).Implements(textMarshalerType)) {
+			return nil, false
+		}
+		rv = rv.Addr()
+	}
+	switch m := rv.Interface().(type) {
+	case json.Marshaler:
+		data, err := m.MarshalJSON()
+		if err != nil {
+			return nil, false
+		}
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, false
+		}
+		return value, true
+	case encoding.TextMarshaler:
+		text, err := m.MarshalText()
+		if err != nil {
+			return nil, false
+		}
+		return string(text), true
+	}
+	return nil, false
+}
+
+// fieldByIndex returns the field at index, or false when it is promoted through a nil embedded pointer.
+func fieldByIndex(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return reflect.Value{}, false
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, true
+}
+
+// isEmptyValue reports whether omitempty drops a value, following encoding/json.
+func isEmptyValue(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return rv.IsZero()
+	}
+	return false
+}
+
+// quotedOption reports whether the options of a json tag include the string option and encoding/json applies it to
+// a field of type t, which must be a boolean, number or string, or a pointer to one.
+func quotedOption(opts string, t reflect.Type) bool {
+	if !slices.Contains(strings.Split(opts, ","), "string") {
+		return false
+	}
+	if t.Name() == "" && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// quotedValue returns the string encoding/json writes for a field with the string option, e.g. "42" for 42.
+func quotedValue(rv reflect.Value) interface{} {
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	// Strings are quoted twice, so the decoded value keeps its JSON quotes
+	data, err := json.Marshal(rv.Interface())
+	if err != nil {
+		return rv.Interface()
+	}
+	return string(data)
+}
+
+// mapKey converts a map key to a string like encoding/json.
+func mapKey(key reflect.Value) string {
+	if key.Kind() == reflect.String {
+		return key.String()
+	}
+	if m, ok := key.Interface().(encoding.TextMarshaler); ok {
+		if text, err := m.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10)
+	}
+	return fmt.Sprint(key.Interface())
+}
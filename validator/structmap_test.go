@@ -0,0 +1,144 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mapBase struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"` // Hidden by mapProduct.Name
+	Version int    `json:"version,string"`
+}
+
+type mapAudit struct {
+	CreatedBy string `json:"created_by"`
+	Conflict  string
+}
+
+type mapOther struct {
+	Conflict string
+}
+
+type mapNode struct {
+	Value int      `json:"value"`
+	Next  *mapNode `json:"next,omitempty"`
+}
+
+type mapProduct struct {
+	mapBase
+	*mapAudit
+	mapOther
+	Name     string               `json:"name"`
+	Price    float64              `json:"price,omitempty"`
+	Stock    int                  `json:"stock,string"`
+	Code     string               `json:"code,string"`
+	Secret   string               `json:"-"`
+	Dash     string               `json:"-,"`
+	Tags     []string             `json:"tags,omitempty"`
+	Data     []byte               `json:"data"`
+	Updated  time.Time            `json:"updated"`
+	IP       netip.Addr           `json:"ip"`
+	Parent   *mapProduct          `json:"parent"`
+	Variants []mapProduct         `json:"variants,omitempty"`
+	Attrs    map[int]string       `json:"attrs,omitempty"`
+	Meta     map[string]any       `json:"meta,omitempty"`
+	Nested   mapNode              `json:"nested"`
+	Pairs    [2]int               `json:"pairs"`
+	Lookup   map[string]*mapOther `json:"lookup,omitempty"`
+	internal string
+}
+
+// jsonRoundTrip returns what decoding the JSON encoding of v into an interface{} gives.
+func jsonRoundTrip(t *testing.T, v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	var result interface{}
+	require.NoError(t, json.Unmarshal(data, &result))
+	return result
+}
+
+func TestStructToMap(t *testing.T) {
+	product := &mapProduct{
+		mapBase:  mapBase{ID: 1, Name: "hidden", Version: 2},
+		mapAudit: &mapAudit{CreatedBy: "alice", Conflict: "dropped"},
+		mapOther: mapOther{Conflict: "dropped"},
+		Name:     "Widget",
+		Stock:    5,
+		Code:     "W-1",
+		Secret:   "secret",
+		Dash:     "dash",
+		Data:     []byte("hi"),
+		Updated:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		IP:       netip.MustParseAddr("10.0.0.1"),
+		Variants: []mapProduct{{Name: "Small", Tags: []string{"s"}}},
+		Attrs:    map[int]string{1: "one"},
+		Nested:   mapNode{Value: 1, Next: &mapNode{Value: 2}},
+		Pairs:    [2]int{3, 4},
+		Lookup:   map[string]*mapOther{"a": {Conflict: "x"}, "b": nil},
+		internal: "internal",
+	}
+
+	t.Run("mirrors encoding/json", func(t *testing.T) {
+		require.Equal(t, jsonRoundTrip(t, product), jsonRoundTrip(t, StructToMap(product)))
+		require.Equal(t, jsonRoundTrip(t, *product), jsonRoundTrip(t, StructToMap(*product)))
+	})
+
+	t.Run("field rules", func(t *testing.T) {
+		m := StructToMap(product)
+		require.Equal(t, 1, m["id"])
+		require.Equal(t, "Widget", m["name"])
+		require.Equal(t, "alice", m["created_by"])
+		require.Equal(t, "2", m["version"])
+		require.Equal(t, "5", m["stock"])
+		require.Equal(t, `"W-1"`, m["code"])
+		require.Equal(t, "dash", m["-"])
+		require.Equal(t, "aGk=", m["data"])
+		require.Equal(t, "2024-01-02T03:04:05Z", m["updated"])
+		require.Equal(t, "10.0.0.1", m["ip"])
+		require.Equal(t, map[string]interface{}{"1": "one"}, m["attrs"])
+		require.Equal(t, []interface{}{3, 4}, m["pairs"])
+		require.Equal(t, map[string]interface{}{"value": 1, "next": map[string]interface{}{"value": 2}}, m["nested"])
+		require.Nil(t, m["parent"])
+		for _, key := range []string{"Secret", "secret", "Conflict", "price", "tags", "meta", "internal"} {
+			require.NotContains(t, m, key)
+		}
+		require.Equal(t, []interface{}{map[string]interface{}{
+			"id": 0, "version": "0", "name": "Small", "stock": "0", "code": `""`, "-": "", "tags": []interface{}{"s"},
+			"data": nil, "updated": "0001-01-01T00:00:00Z", "ip": "", "parent": nil, "nested": map[string]interface{}{"value": 0},
+			"pairs": []interface{}{0, 0},
+		}}, m["variants"])
+	})
+
+	t.Run("nil embedded pointers are skipped", func(t *testing.T) {
+		m := StructToMap(mapProduct{})
+		require.NotContains(t, m, "created_by")
+	})
+
+	t.Run("non-struct values", func(t *testing.T) {
+		var nilProduct *mapProduct
+		require.Nil(t, StructToMap(nilProduct))
+		require.Nil(t, StructToMap(nil))
+		require.Nil(t, StructToMap(42))
+		require.Nil(t, StructToMap(map[string]interface{}{"a": 1}))
+	})
+
+	t.Run("pointer cycles are cut", func(t *testing.T) {
+		cyclic := &mapProduct{Name: "loop"}
+		cyclic.Parent = cyclic
+		m := StructToMap(cyclic)
+		require.Nil(t, m["parent"])
+	})
+
+	t.Run("EachWithOptions validates pointers to structs", func(t *testing.T) {
+		options := Object(Field("items").ArrayOf(Field("name").MinLength(3), Field("code").Optional()))
+		err := ValidateAll(map[string]interface{}{
+			"items": []*mapProduct{{Name: "Widget"}, {Name: "ab"}, nil},
+		}, options)
+		require.EqualError(t, err, "value must be at least 3 characters long; element at index 2 must be an object, got *validator.mapProduct")
+	})
+}
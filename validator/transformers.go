@@ -1,6 +1,8 @@
 package validator
 
 import (
+	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
@@ -25,6 +27,44 @@ func applyToArrayOrValue(value any, transform func(any) any) any {
 	return transform(value)
 }
 
+// tryToArrayOrValue is like applyToArrayOrValue for transformations that can fail, reporting the index of the failing element
+func tryToArrayOrValue(value any, transform func(any) (any, error)) (any, error) {
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Slice {
+		result := make([]any, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			transformed, err := transform(v.Index(i).Interface())
+			if err != nil {
+				if e, ok := err.(*ValidationError); ok {
+					e.Field = fmt.Sprintf("[%d]", i)
+				}
+				return value, err
+			}
+			result[i] = transformed
+		}
+		return result, nil
+	}
+	return transform(value)
+}
+
+// Fallible adapts a transformer to a FallibleTransformer that never fails
+func Fallible(transformer Transformer) FallibleTransformer {
+	return func(value any) (any, error) {
+		return transformer(value), nil
+	}
+}
+
+// Lenient adapts a FallibleTransformer to a transformer that keeps the value unchanged when the transformation fails
+func Lenient(transformer FallibleTransformer) Transformer {
+	return func(value any) any {
+		transformed, err := transformer(value)
+		if err != nil {
+			return value
+		}
+		return transformed
+	}
+}
+
 // ToLower transforms a string or array of strings to lowercase
 func ToLower(value any) any {
 	return applyToArrayOrValue(value, func(v any) any {
@@ -112,6 +152,83 @@ func ToFloat(value any) any {
 	})
 }
 
+// ToIntStrict converts a string/number or array of strings/numbers to integer(s), failing on unparsable strings and fractional
+// or out of range numbers. Numbers of any Go numeric type, json.Number and math/big values are converted exactly.
+func ToIntStrict(value any) (any, error) {
+	return tryToArrayOrValue(value, func(v any) (any, error) {
+		if val, ok := v.(string); ok {
+			if i, err := strconv.Atoi(val); err == nil {
+				return i, nil
+			}
+		} else if r, ok := toDecimal(v); ok && r.IsInt() && r.Num().IsInt64() {
+			if i := r.Num().Int64(); i == int64(int(i)) {
+				return int(i), nil
+			}
+		}
+		return v, invalidType("ToIntStrict", "convert_int", v, nil)
+	})
+}
+
+// ToFloatStrict converts a string/number or array of strings/numbers to float(s), failing on unparsable strings and numbers
+// out of the range of float64. Numbers of any Go numeric type, json.Number and math/big values are converted.
+func ToFloatStrict(value any) (any, error) {
+	return tryToArrayOrValue(value, func(v any) (any, error) {
+		if val, ok := v.(string); ok {
+			if f, err := strconv.ParseFloat(val, 64); err == nil {
+				return f, nil
+			}
+		} else if r, ok := toDecimal(v); ok {
+			if f, _ := r.Float64(); !math.IsInf(f, 0) {
+				return f, nil
+			}
+		}
+		return v, invalidType("ToFloatStrict", "convert_float", v, nil)
+	})
+}
+
+// ToBoolStrict converts a string such as "true" or "0" or array of strings to boolean(s), failing on other values
+func ToBoolStrict(value any) (any, error) {
+	return tryToArrayOrValue(value, func(v any) (any, error) {
+		switch val := v.(type) {
+		case bool:
+			return val, nil
+		case string:
+			if b, err := strconv.ParseBool(val); err == nil {
+				return b, nil
+			}
+		}
+		return v, invalidType("ToBoolStrict", "convert_bool", v, nil)
+	})
+}
+
+// ToDecimal converts a decimal string/number or array of them to canonical decimal string(s) such as "-12.5",
+// without exponent, plus sign, leading or trailing zeros, failing on other values
+func ToDecimal(value any) (any, error) {
+	return tryToArrayOrValue(value, func(v any) (any, error) {
+		if r, ok := toDecimal(v); ok {
+			return formatDecimal(r, 0), nil
+		}
+		return v, invalidType("ToDecimal", "convert_decimal", v, nil)
+	})
+}
+
+// ToFixedDecimal converts a decimal string/number or array of them to decimal string(s) with exactly places decimal places such as "12.50",
+// failing on other values and on values with more decimal places rather than rounding them
+func ToFixedDecimal(places int) FallibleTransformer {
+	return func(value any) (any, error) {
+		return tryToArrayOrValue(value, func(v any) (any, error) {
+			r, ok := toDecimal(v)
+			if !ok {
+				return v, invalidType("ToFixedDecimal", "convert_decimal", v, nil)
+			}
+			if n, _ := decimalPlaces(r); n > places {
+				return v, invalid("ToFixedDecimal", "decimal_places", v, Params{"places": places})
+			}
+			return r.FloatString(places), nil
+		})
+	}
+}
+
 // Truncate truncates a string or array of strings to a specified maximum length
 func Truncate(maxLength int) Transformer {
 	return func(value any) any {
@@ -1,8 +1,13 @@
 package validator
 
 import (
+	"encoding/json"
+	"errors"
+	"math"
 	"reflect"
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestToLower(t *testing.T) {
@@ -162,6 +167,120 @@ func TestToFloat(t *testing.T) {
 	}
 }
 
+func TestStrictConversions(t *testing.T) {
+	tests := []struct {
+		name        string
+		transformer FallibleTransformer
+		input       any
+		expected    any
+		error       error
+	}{
+		{"int from string", ToIntStrict, "123", 123, nil},
+		{"int from whole float", ToIntStrict, 12.0, 12, nil},
+		{"int from array", ToIntStrict, []any{"1", 2.0}, []any{1, 2}, nil},
+		{"int from invalid string", ToIntStrict, "abc", "abc", errors.New("value cannot be converted to an integer")},
+		{"int from fractional float", ToIntStrict, 1.5, 1.5, errors.New("value cannot be converted to an integer")},
+		{"int from array with invalid element", ToIntStrict, []any{"1", "x"}, []any{"1", "x"}, errors.New("value cannot be converted to an integer")},
+		{"int from json.Number", ToIntStrict, json.Number("42"), 42, nil},
+		{"int from json.Number with exponent", ToIntStrict, json.Number("1.2e3"), 1200, nil},
+		{"int from fractional json.Number", ToIntStrict, json.Number("1.5"), json.Number("1.5"), errors.New("value cannot be converted to an integer")},
+		{"int from int64", ToIntStrict, int64(-7), -7, nil},
+		{"int from int32", ToIntStrict, int32(7), 7, nil},
+		{"int from uint", ToIntStrict, uint(7), 7, nil},
+		{"int from float32", ToIntStrict, float32(3), 3, nil},
+		{"int out of range", ToIntStrict, uint64(math.MaxUint64), uint64(math.MaxUint64), errors.New("value cannot be converted to an integer")},
+		{"int from big json.Number", ToIntStrict, json.Number("9223372036854775808"), json.Number("9223372036854775808"), errors.New("value cannot be converted to an integer")},
+		{"float from string", ToFloatStrict, "1.5", 1.5, nil},
+		{"float from json.Number", ToFloatStrict, json.Number("-2.5e-1"), -0.25, nil},
+		{"float from float32", ToFloatStrict, float32(0.1), 0.1, nil},
+		{"float from int64", ToFloatStrict, int64(1 << 40), float64(1 << 40), nil},
+		{"float from uint", ToFloatStrict, uint(3), 3.0, nil},
+		{"float out of range", ToFloatStrict, json.Number("1e400"), json.Number("1e400"), errors.New("value cannot be converted to a number")},
+		{"float from int", ToFloatStrict, 2, 2.0, nil},
+		{"float from invalid string", ToFloatStrict, "1,5", "1,5", errors.New("value cannot be converted to a number")},
+		{"float from bool", ToFloatStrict, true, true, errors.New("value cannot be converted to a number")},
+		{"bool from string", ToBoolStrict, "false", false, nil},
+		{"bool from bool", ToBoolStrict, true, true, nil},
+		{"bool from invalid string", ToBoolStrict, "yes", "yes", errors.New("value cannot be converted to a boolean")},
+		{"decimal from string", ToDecimal, "+012.50", "12.5", nil},
+		{"decimal from json.Number", ToDecimal, json.Number("-1.25e1"), "-12.5", nil},
+		{"decimal from float", ToDecimal, 0.1, "0.1", nil},
+		{"decimal from large int", ToDecimal, uint64(math.MaxUint64), "18446744073709551615", nil},
+		{"decimal from zero", ToDecimal, json.Number("-0.00"), "0", nil},
+		{"decimal from invalid string", ToDecimal, "1,5", "1,5", errors.New("value cannot be converted to a decimal")},
+		{"decimal from infinity", ToDecimal, math.Inf(1), math.Inf(1), errors.New("value cannot be converted to a decimal")},
+		{"fixed decimal from string", ToFixedDecimal(2), "12.5", "12.50", nil},
+		{"fixed decimal from int", ToFixedDecimal(2), 12, "12.00", nil},
+		{"fixed decimal from array", ToFixedDecimal(1), []any{"1", 2.25e1}, []any{"1.0", "22.5"}, nil},
+		{"fixed decimal with trailing zeros", ToFixedDecimal(2), "0.1000", "0.10", nil},
+		{"fixed decimal with more places", ToFixedDecimal(2), "12.505", "12.505", errors.New("value must have at most 2 decimal places")},
+		{"nil", ToIntStrict, nil, nil, errors.New("value cannot be converted to an integer")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := test.transformer(test.input)
+			require.Equal(t, test.expected, result)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
+		})
+	}
+}
+
+func TestTransformerAdapters(t *testing.T) {
+	result, err := Fallible(Trim)(" a ")
+	require.NoError(t, err)
+	require.Equal(t, "a", result)
+
+	require.Equal(t, "abc", Lenient(ToIntStrict)("abc"))
+	require.Equal(t, 12, Lenient(ToIntStrict)("12"))
+}
+
+func TestFallibleTransformers(t *testing.T) {
+	options := Object(
+		Field("quantity").Trim().ToIntStrict().Min(1),
+		Field("ids").Optional().ToIntStrict().Transform(func(value any) any { return len(value.([]any)) }),
+	)
+
+	t.Run("transformed values are validated and written back", func(t *testing.T) {
+		body := map[string]interface{}{"quantity": " 3 ", "ids": []any{"1", "2"}}
+		require.NoError(t, Validate(body, options))
+		require.Equal(t, map[string]interface{}{"quantity": 3, "ids": 2}, body)
+	})
+
+	t.Run("failures are reported with the field path", func(t *testing.T) {
+		body := map[string]interface{}{"quantity": " three ", "ids": []any{"1", "x"}}
+		err := ValidateAll(body, options)
+		require.EqualError(t, err, "value cannot be converted to an integer; value cannot be converted to an integer")
+
+		var errs ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		first, second := errs[0].(*ValidationError), errs[1].(*ValidationError)
+		require.Equal(t, "quantity", first.Field)
+		require.Equal(t, "ToIntStrict", first.Rule)
+		require.Equal(t, CodeInvalidType, first.Code)
+		require.Equal(t, "ids[1]", second.Field)
+		require.Equal(t, "three", body["quantity"])
+	})
+
+	t.Run("plain errors are wrapped", func(t *testing.T) {
+		options := []ValidationOption{{
+			Key: "code",
+			FallibleTransformers: []FallibleTransformer{func(value interface{}) (interface{}, error) {
+				return nil, errors.New("unknown code")
+			}},
+		}}
+		err := Validate(map[string]interface{}{"code": "x"}, options)
+		var e *ValidationError
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, "unknown code", e.Message)
+		require.Equal(t, CodeInvalid, e.Code)
+	})
+}
+
 func TestTruncate(t *testing.T) {
 	transformer := Truncate(5)
 	tests := []struct {
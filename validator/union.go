@@ -0,0 +1,123 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Branch is one of the option sets of a discriminated union, see Discriminator and OneOf.
+type Branch struct {
+	Name    string             // Value of the discriminator selecting the branch, or label reported when no branch matches
+	Value   interface{}        // Non-string value of the discriminator selecting the branch instead of Name, see CaseValue
+	Options []ValidationOption // Options of the fields the branch adds to the object
+}
+
+// Case builds the branch selected by the discriminator value name from field specs.
+func Case(name string, fields ...FieldSpec) Branch {
+	return Branch{Name: name, Options: Object(fields...)}
+}
+
+// CaseValue builds the branch selected by a discriminator value that is not a string, such as 1 or true, from field specs.
+// Numbers are compared by value, so CaseValue(1, ...) matches the float64 1 of decoded JSON.
+func CaseValue(value interface{}, fields ...FieldSpec) Branch {
+	return Branch{Name: fmt.Sprint(value), Value: value, Options: Object(fields...)}
+}
+
+// tag returns the discriminator value selecting the branch.
+func (b Branch) tag() interface{} {
+	if b.Value != nil {
+		return b.Value
+	}
+	return b.Name
+}
+
+// Discriminator starts building a field whose value picks the branch validating the rest of the object, e.g.
+//
+//	validator.Discriminator("type",
+//		validator.Case("card", validator.Field("number").CreditCard()),
+//		validator.Case("bank_transfer", validator.Field("iban").String()),
+//	)
+//
+// The fields of the branch are validated after the discriminator like fields declared next to it, and their failures
+// name the branch in their "branch" parameter.
+// A value naming no branch is reported on the discriminator, an absent optional discriminator picks no branch.
+func Discriminator(key string, branches ...Branch) *FieldBuilder {
+	b := Field(key)
+	b.option.Branches = branches
+	return b
+}
+
+// OneOf returns an option matching the object holding it against the branches in order, keeping the first that passes.
+// The transformations of the other branches are discarded. When none passes, the object is reported with the failures
+// of every branch in the "errors" parameter, e.g. "value matches none of the alternatives: card (number is required); ...".
+func OneOf(branches ...Branch) ValidationOption {
+	return ValidationOption{Branches: branches}
+}
+
+// pickBranch validates the fields of the branch named by the discriminator at fieldPath, an option of the object at path,
+// returning their options. Values are compared like UniqueItems does, numbers by value. A discriminator without value
+// picks no branch. The failures of the fields of the branch carry its name in the "branch" parameter.
+func (v *validation) pickBranch(body map[string]interface{}, option ValidationOption, fieldPath, path string, policy UnknownKeys) ([]ValidationOption, bool) {
+	value := body[option.Key]
+	if value == nil {
+		return nil, true
+	}
+	key, ok := equalityKey(value)
+	names := make([]string, len(option.Branches))
+	for i, branch := range option.Branches {
+		if tag, _ := equalityKey(branch.tag()); ok && tag == key {
+			failures := len(v.errs)
+			declared, ok := v.fields(body, branch.Options, path, policy)
+			for _, err := range v.errs[failures:] {
+				e := err.(*ValidationError)
+				if e.Params == nil {
+					e.Params = Params{}
+				}
+				e.Params["branch"] = branch.Name
+			}
+			return declared, ok
+		}
+		names[i] = branch.Name
+	}
+	e := invalid("Discriminator", "discriminator", value, Params{"field": option.Key, "values": names})
+	e.Field = fieldPath
+	return nil, v.fail(e)
+}
+
+// tryBranches validates copies of the object at path against the branches in order and keeps the values of the first
+// that passes, returning the options of its fields. When none passes, the failures of every branch are reported.
+func (v *validation) tryBranches(body map[string]interface{}, branches []Branch, path string, policy UnknownKeys) ([]ValidationOption, bool) {
+	errs := make(ValidationErrors, 0, len(branches))
+	for _, branch := range branches {
+		trial := deepCopy(body).(map[string]interface{})
+		try := &validation{ctx: v.ctx, collectAll: true, root: v.root, rooted: v.rooted, translator: v.translator, unknownKeys: v.unknownKeys, refs: v.refs, baseDepth: v.baseDepth}
+		if sameObject(body, v.root) {
+			try.root, try.rooted = trial, false
+		}
+		declared, _ := try.fields(trial, branch.Options, path, policy)
+		if try.err != nil {
+			return nil, v.abort(try.err)
+		}
+		if len(try.errs) == 0 {
+			for _, option := range declared {
+				if value, exists := trial[option.Key]; exists {
+					body[option.Key] = value
+				}
+			}
+			return declared, true
+		}
+		e := invalid("OneOf", "branch", nil, Params{"branch": branch.Name, "errors": try.errs})
+		e.Err = errors.Join(try.errs...)
+		errs = append(errs, e)
+	}
+	e := invalid("OneOf", "any_of", nil, Params{"errors": errs})
+	e.Field = path
+	e.Err = errors.Join(errs...)
+	return nil, v.fail(e)
+}
+
+// sameObject reports whether a and b are the same map rather than equal ones.
+func sameObject(a, b map[string]interface{}) bool {
+	return reflect.ValueOf(a).UnsafePointer() == reflect.ValueOf(b).UnsafePointer()
+}
@@ -0,0 +1,174 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscriminator(t *testing.T) {
+	options := Object(
+		Field("amount").Int().Min(1),
+		Discriminator("type",
+			Case("card", Field("number").CreditCard(), Field("cvc").Length(3, 4)),
+			Case("bank_transfer", Field("iban").Trim().MinLength(15), Field("reference").Optional().Default("n/a")),
+		).Trim().ToLower(),
+	)
+
+	tests := []struct {
+		name string
+		body map[string]interface{}
+		err  string
+	}{
+		{"card", map[string]interface{}{"amount": 10, "type": "card", "number": "4111111111111111", "cvc": "123"}, ""},
+		{"bank transfer", map[string]interface{}{"amount": 10, "type": " Bank_Transfer ", "iban": "FR7630006000011234567890189"}, ""},
+		{"fields of the chosen branch", map[string]interface{}{"amount": 10, "type": "card", "cvc": "1"}, "number is required; value must be between 3 and 4 characters long"},
+		{"unknown type", map[string]interface{}{"amount": 10, "type": "cash"}, "type must be one of [card bank_transfer]"},
+		{"missing type", map[string]interface{}{"amount": 0}, "value must be greater than or equal to 1; type is required"},
+		{"invalid type", map[string]interface{}{"amount": 10, "type": 3}, "type must be one of [card bank_transfer]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateAll(test.body, options)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.err)
+			}
+		})
+	}
+
+	t.Run("transformed branch fields and paths", func(t *testing.T) {
+		body := map[string]interface{}{
+			"payment": map[string]interface{}{"type": "bank_transfer", "iban": " FR7630006000011234567890189 "},
+		}
+		err := Validate(body, Object(Field("payment").Object(options[1])))
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"type": "bank_transfer", "iban": "FR7630006000011234567890189", "reference": "n/a"}, body["payment"])
+
+		err = Validate(map[string]interface{}{"payment": map[string]interface{}{"type": "card"}}, Object(Field("payment").Object(options[1])))
+		var ve *ValidationError
+		require.ErrorAs(t, err, &ve)
+		require.Equal(t, "payment.number", ve.Field)
+	})
+
+	t.Run("strict objects accept the fields of the chosen branch", func(t *testing.T) {
+		body := map[string]interface{}{"amount": 10, "type": "card", "number": "4111111111111111", "cvc": "123", "iban": "x"}
+		require.NoError(t, Validate(body, options))
+		require.EqualError(t, ValidateContext(context.Background(), body, options, Strict()), "iban is not allowed")
+
+		delete(body, "iban")
+		require.NoError(t, ValidateContext(context.Background(), body, options, Strict()))
+	})
+
+	t.Run("arrays of unions", func(t *testing.T) {
+		items := Field("items").ArrayOf(
+			Discriminator("kind",
+				Case("text", Field("text").String()),
+				Case("image", Field("url").URL()),
+			),
+		)
+		body := map[string]interface{}{"items": []interface{}{
+			map[string]interface{}{"kind": "text", "text": "hello"},
+			map[string]interface{}{"kind": "image", "url": "nope"},
+			map[string]interface{}{"kind": "video"},
+		}}
+		err := ValidateAll(body, Object(items))
+		var errs ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 2)
+		require.Equal(t, "items[1].url", errs[0].(*ValidationError).Field)
+		require.Equal(t, "items[2].kind", errs[1].(*ValidationError).Field)
+		require.Equal(t, "Discriminator", errs[1].(*ValidationError).Rule)
+		require.Equal(t, "image", errs[0].(*ValidationError).Params["branch"])
+	})
+
+	t.Run("failures name the chosen branch", func(t *testing.T) {
+		err := ValidateAll(map[string]interface{}{"amount": 10, "type": "card", "cvc": "1"}, options)
+		var errs ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		for _, e := range errs {
+			require.Equal(t, "card", e.(*ValidationError).Params["branch"])
+		}
+	})
+
+	t.Run("non-string tags", func(t *testing.T) {
+		options := Object(Discriminator("version",
+			CaseValue(1, Field("name").String()),
+			CaseValue(2, Field("first_name").String()),
+		), Discriminator("draft",
+			CaseValue(true),
+			CaseValue(false, Field("published_at").Date()),
+		))
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(`{"version": 2, "first_name": "Ann", "draft": true}`), &body))
+		require.NoError(t, Validate(body, options))
+
+		err := Validate(map[string]interface{}{"version": json.Number("1.0"), "draft": false, "name": "Ann"}, options)
+		var ve *ValidationError
+		require.ErrorAs(t, err, &ve)
+		require.Equal(t, "published_at", ve.Field)
+		require.Equal(t, "false", ve.Params["branch"])
+
+		err = Validate(map[string]interface{}{"version": "1", "draft": true}, options)
+		require.EqualError(t, err, "version must be one of [1 2]")
+	})
+}
+
+func TestOneOf(t *testing.T) {
+	options := Object(
+		Field("id").String(),
+		OneOf(
+			Case("email", Field("email").Trim().Email()),
+			Case("phone", Field("phone").Trim().Regex(`^\+[0-9]+$`), Field("country").Optional().Default("FR")),
+		),
+	)
+
+	t.Run("first matching branch", func(t *testing.T) {
+		body := map[string]interface{}{"id": "1", "email": " user@example.com ", "phone": " +33 "}
+		require.NoError(t, Validate(body, options))
+		require.Equal(t, map[string]interface{}{"id": "1", "email": "user@example.com", "phone": " +33 "}, body)
+	})
+
+	t.Run("later branch", func(t *testing.T) {
+		body := map[string]interface{}{"id": "1", "phone": " +33123 "}
+		require.NoError(t, ValidateContext(context.Background(), body, options, Strict()))
+		require.Equal(t, map[string]interface{}{"id": "1", "phone": "+33123", "country": "FR"}, body)
+	})
+
+	t.Run("no matching branch", func(t *testing.T) {
+		body := map[string]interface{}{"id": "1", "phone": "0612"}
+		err := ValidateAll(body, options)
+		require.EqualError(t, err, "value matches none of the alternatives: email (email is required); phone (value does not match the required pattern)")
+		require.Equal(t, map[string]interface{}{"id": "1", "phone": "0612"}, body)
+
+		var errs ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		e := errs[0].(*ValidationError)
+		require.Equal(t, "", e.Field)
+		require.Equal(t, "OneOf", e.Rule)
+
+		branches := e.Params["errors"].(ValidationErrors)
+		require.Len(t, branches, 2)
+		phone := branches[1].(*ValidationError)
+		require.Equal(t, "phone", phone.Params["branch"])
+		require.Equal(t, "phone", phone.Params["errors"].(ValidationErrors)[0].(*ValidationError).Field)
+	})
+
+	t.Run("localized", func(t *testing.T) {
+		err := ValidateContext(context.Background(), map[string]interface{}{"id": "1"}, options, WithLocale("fr"))
+		require.EqualError(t, err, "la valeur ne correspond à aucune des alternatives : email (email est obligatoire); phone (phone est obligatoire)")
+	})
+
+	t.Run("compiled", func(t *testing.T) {
+		schema, err := Compile(options)
+		require.NoError(t, err)
+		require.NoError(t, schema.Validate(map[string]interface{}{"id": "1", "email": "user@example.com"}))
+
+		_, err = Compile(Object(OneOf(Case("a", Field("x")), Case("a", Field(""))), Discriminator("type", Case("b"), Case("b"))))
+		require.EqualError(t, err, "[0]: duplicate branch \"a\"\n[0]<a>[0]: option has no key\ntype: duplicate branch \"b\"")
+	})
+}
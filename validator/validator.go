@@ -1,86 +1,421 @@
 package validator
 
 import (
-	"fmt"
-	"reflect"
-	"strings"
+	"context"
+	"slices"
 )
 
 // ValidatorFunc is a function that validates a field and returns an error if validation fails.
 type ValidatorFunc func(value interface{}) error
 
+// ContextValidatorFunc is a validator that needs a context, e.g. to look a value up in a database.
+// Wrap infrastructure failures with Internal so they abort validation instead of being reported as invalid input.
+type ContextValidatorFunc func(ctx context.Context, value interface{}) error
+
+// CrossFieldFunc is a function that validates a field against the other fields of the request body.
+type CrossFieldFunc func(value interface{}, field FieldContext) error
+
+// FieldContext gives cross-field validators access to the field's siblings and to the whole request body.
+type FieldContext struct {
+	Key    string                 // Field name in the request body
+	Exists bool                   // Whether the field is present
+	Parent map[string]interface{} // Object holding the field and its siblings
+	Root   map[string]interface{} // Top-level request body
+}
+
 // TransformerFunc is a function that transforms a value.
 type Transformer func(value interface{}) interface{}
 
+// FallibleTransformer is a function that transforms a value or returns an error, reported like a validator failure,
+// when the value can't be transformed. Use Fallible and Lenient to convert between transformer kinds.
+type FallibleTransformer func(value interface{}) (interface{}, error)
+
 // Validator defines a validator function and its error message.
+// Exactly one of Func, ContextFunc and CrossFunc is set.
+// Message may contain placeholders such as "{field} must be at least {min} characters (got {len})", see CreateValidator.
 type Validator struct {
-	Func    ValidatorFunc
-	Message string
+	Func        ValidatorFunc
+	ContextFunc ContextValidatorFunc // Validator receiving the context passed to ValidateContext
+	CrossFunc   CrossFieldFunc       // Cross-field validator, also run when an optional field is absent
+	Message     string
+	Rule        string // Rule name reported in ValidationError, derived from the function when empty
+	Expensive   bool   // Whether the validator runs on the pool set up by the Concurrency run option
+
+	elements    []ValidationOption // Options of the elements of an ArrayOf field, checked by Compile
+	elementsRef string             // Definition of the elements of an ArrayOfRef field, checked by Compile
+}
+
+// rule returns the rule name reported when the validator fails.
+func (v Validator) rule() string {
+	if v.Rule != "" {
+		return v.Rule
+	}
+	if v.ContextFunc != nil {
+		return ruleName(v.ContextFunc)
+	}
+	if v.CrossFunc != nil {
+		return ruleName(v.CrossFunc)
+	}
+	return ruleName(v.Func)
+}
+
+// run applies the validator to the value of a field.
+func (v Validator) run(ctx context.Context, value interface{}, field FieldContext) error {
+	if v.ContextFunc != nil {
+		return v.ContextFunc(ctx, value)
+	}
+	if v.CrossFunc != nil {
+		return v.CrossFunc(value, field)
+	}
+	return v.Func(value)
 }
 
 // ValidationOption defines the validation rules for a specific field.
 type ValidationOption struct {
-	Key          string             // Field name in the request body
-	IsOptional   bool               // Whether the field is optional
-	Validators   []Validator        // List of validators for the field
-	Transformers []Transformer      // List of transformers for the field
-	Nested       []ValidationOption // Validation options for nested objects
+	Key                  string                // Field name in the request body
+	IsOptional           bool                  // Whether the field is optional
+	Validators           []Validator           // List of validators for the field
+	Transformers         []Transformer         // List of transformers for the field
+	FallibleTransformers []FallibleTransformer // Transformers that can fail, applied after Transformers
+	Nested               []ValidationOption    // Validation options for nested objects
+	UnknownKeys          UnknownKeys           // Policy for keys of the nested objects that no option declares
+	Nullable             bool                  // Whether the field accepts null, which skips its transformers, validators and nested options
+	Default              interface{}           // Value written into the body when the field is absent, a func() interface{} is called instead
+	Branches             []Branch              // Option sets for the rest of the object picked by the field's value, or tried in order without Key
+	Ref                  string                // Name of the Registry definition validating the field as a nested object, instead of Nested
 }
 
-// Validate checks the request body against the validation options and returns the first error.
+// Validate checks the request body against the validation options and returns the first error as a *ValidationError.
 func Validate(body map[string]interface{}, options []ValidationOption) error {
-	for _, option := range options {
-		value, exists := body[option.Key]
+	return ValidateContext(context.Background(), body, options)
+}
+
+// ValidateAll checks the request body against the validation options and returns every failure
+// as ValidationErrors of *ValidationError, including the ones found in Nested objects and EachWithOptions elements.
+func ValidateAll(body map[string]interface{}, options []ValidationOption) error {
+	return ValidateContext(context.Background(), body, options, CollectAll())
+}
+
+// RunOption configures a single ValidateContext run.
+type RunOption func(v *validation)
+
+// CollectAll makes ValidateContext return every failure as ValidationErrors, like ValidateAll.
+func CollectAll() RunOption {
+	return func(v *validation) {
+		v.collectAll = true
+	}
+}
+
+// ValidateContext checks the request body against the validation options, passing ctx to context validators.
+// It returns the first error as a *ValidationError, or every failure with CollectAll.
+// Validation stops with the context's error once ctx is done, and with the *InternalError of a failing context validator.
+func ValidateContext(ctx context.Context, body map[string]interface{}, options []ValidationOption, opts ...RunOption) error {
+	v := &validation{}
+	v.reset(ctx, body)
+	v.apply(opts)
+	return v.run(body, options)
+}
+
+// reset prepares v for a run validating body with ctx.
+func (v *validation) reset(ctx context.Context, body map[string]interface{}) {
+	*v = validation{ctx: ctx, root: body, translator: DefaultCatalog}
+	if root, ok := ctx.Value(rootKey{}).(map[string]interface{}); ok {
+		// A nested run such as an ArrayOf element resolves "$." paths from the request body
+		v.root, v.rooted = root, true
+	}
+	if policy, ok := ctx.Value(unknownKeysKey{}).(UnknownKeys); ok {
+		v.unknownKeys = policy
+	}
+	v.refs, _ = ctx.Value(refsKey{}).(refs)
+	v.baseDepth = v.refs.depth
+}
+
+// apply applies run options to v.
+func (v *validation) apply(opts []RunOption) {
+	for _, opt := range opts {
+		opt(v)
+	}
+}
+
+// run validates body against options and returns the result of ValidateContext.
+func (v *validation) run(body map[string]interface{}, options []ValidationOption) error {
+	if v.unknownKeys != InheritUnknownKeys {
+		v.ctx = context.WithValue(v.ctx, unknownKeysKey{}, v.unknownKeys)
+	}
+	if collectAll, _ := v.ctx.Value(collectAllKey{}).(bool); collectAll != v.collectAll {
+		v.ctx = context.WithValue(v.ctx, collectAllKey{}, v.collectAll)
+	}
+	if r, _ := v.ctx.Value(refsKey{}).(refs); r != v.refs {
+		v.ctx = context.WithValue(v.ctx, refsKey{}, v.refs)
+	}
+	if v.concurrency > 1 {
+		v.pool = newPool(v.concurrency, v.collectAll)
+	}
+	v.object(body, options, "", v.unknownKeys)
+	if v.pool != nil {
+		v.merge()
+	}
+	if v.err != nil {
+		return v.err
+	}
+	if len(v.locales) > 0 {
+		for i, err := range v.errs {
+			v.errs[i] = localize(err.(*ValidationError), v.translator, v.locales)
+		}
+	}
+	if len(v.errs) == 0 {
+		return nil
+	}
+	if !v.collectAll {
+		return v.errs[0]
+	}
+	return v.errs
+}
+
+// collectAllKey is the context key telling nested runs such as EachWithOptionsContext elements whether the run
+// collects every failure. Its absence means the run stops at the first one.
+type collectAllKey struct{}
+
+// collectsAll reports whether the run of ctx collects every failure.
+func collectsAll(ctx context.Context) bool {
+	collectAll, _ := ctx.Value(collectAllKey{}).(bool)
+	return collectAll
+}
+
+// rootKey is the context key carrying the root of the request body into nested runs such as ArrayOf elements.
+type rootKey struct{}
+
+// validation holds the state of a single validation run.
+type validation struct {
+	ctx         context.Context
+	collectAll  bool
+	concurrency int
+	root        map[string]interface{}
+	rooted      bool // Whether ctx carries root, which is only added once a context validator needs it
+	errs        ValidationErrors
+	err         error // Context or infrastructure error that aborted the run
+	pool        *pool // Pool running expensive validators, nil unless Concurrency is set
+	jobs        []*job
+	order       int
+	locales     []string
+	translator  Translator
+	unknownKeys UnknownKeys    // Policy for the keys of the request body
+	refs        refs           // References followed to reach the object being validated
+	baseDepth   int            // Depth of the references in ctx
+	visiting    map[visit]bool // Structs ValidateStruct is validating, to cut pointer cycles
+}
+
+// abort stops validation because of a context or infrastructure error.
+func (v *validation) abort(err error) bool {
+	v.err = err
+	return false
+}
 
-		// Skip validation if the field is optional and not present
-		if option.IsOptional && !exists {
-			continue
+// fail records a failure and reports whether validation should go on.
+func (v *validation) fail(err *ValidationError) bool {
+	v.errs = append(v.errs, err)
+	return v.collectAll
+}
+
+// failValidator records the error returned by a validator of the field at path and reports whether validation should go on.
+func (v *validation) failValidator(path string, value interface{}, validator Validator, err error) bool {
+	if isInternal(err) {
+		return v.abort(err)
+	}
+
+	rule := validator.rule()
+	e, _ := err.(*ValidationError)
+
+	// A custom message replaces whatever the validator reported, its placeholders are filled from the failure
+	if validator.Message != "" {
+		message := render(validator.Message, messageParams(path, value, err))
+		custom := &ValidationError{Field: path, Rule: rule, Code: CodeInvalid, Value: value, Message: message, Err: err}
+		if e != nil && e.Field == "" {
+			custom.Code, custom.Params = e.Code, e.Params
+		}
+		return v.fail(custom)
+	}
+
+	// Validators such as EachWithOptions report every failure they found
+	if errs, ok := err.(ValidationErrors); ok {
+		for _, e := range errs {
+			if !v.failValidator(path, value, validator, e) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// Built-in rules report structured failures, and Each or EachWithOptions report them relative to the field
+	if e != nil {
+		own := e.Field == ""
+		e = withPath(path, e)
+		if own && validator.Rule != "" {
+			e.Rule = validator.Rule
+		}
+		if own && e.Value == nil {
+			e.Value = value
 		}
+		return v.fail(e)
+	}
+
+	return v.fail(&ValidationError{Field: path, Rule: rule, Code: CodeInvalid, Value: value, Message: err.Error(), Err: err})
+}
 
-		// Check if the field is required but missing *before* running validators
-		if !option.IsOptional && !exists {
-			return fmt.Errorf("%s is required", option.Key)
+// object validates body against options, applying policy to its unknown keys, and reports whether validation should go on.
+func (v *validation) object(body map[string]interface{}, options []ValidationOption, path string, policy UnknownKeys) bool {
+	declared, ok := v.fields(body, options, path, policy)
+	if !ok {
+		return false
+	}
+	return v.checkUnknownKeys(body, declared, path, policy)
+}
+
+// fields validates the fields of body declared by options and by the branches they pick, see Discriminator and OneOf.
+// It returns the options of all these fields and whether validation should go on.
+func (v *validation) fields(body map[string]interface{}, options []ValidationOption, path string, policy UnknownKeys) ([]ValidationOption, bool) {
+	// Absent fields take their default before any field is transformed or validated, so cross-field validators see them
+	for _, option := range options {
+		if _, exists := body[option.Key]; !exists && option.Default != nil {
+			if body == nil {
+				// A nil map cannot take the default, so the object itself is reported
+				e := invalidType("Default", "nil", nil, nil)
+				e.Field = path
+				return nil, v.fail(e)
+			}
+			body[option.Key] = defaultValue(option.Default)
+		}
+	}
+	declared := options
+	for _, option := range options {
+		if err := v.ctx.Err(); err != nil {
+			return nil, v.abort(err)
+		}
+		if v.stopped() {
+			return nil, false
 		}
 
-		// Apply transformations
-		if exists {
-			for _, transformer := range option.Transformers {
-				value = transformer(value)
+		var branch []ValidationOption
+		ok := true
+		if option.Key == "" {
+			branch, ok = v.tryBranches(body, option.Branches, path, policy)
+		} else {
+			failures := len(v.errs)
+			fieldPath := joinPath(path, option.Key)
+			if !v.field(body, option, fieldPath, policy) {
+				return nil, false
+			}
+			if option.Branches != nil && len(v.errs) == failures {
+				branch, ok = v.pickBranch(body, option, fieldPath, path, policy)
 			}
-			body[option.Key] = value // Update the body with the transformed value
 		}
+		if !ok {
+			return nil, false
+		}
+		declared = append(slices.Clip(declared), branch...)
+	}
+	return declared, true
+}
+
+// field validates a single option against body, an object applying policy, and reports whether validation should go on.
+func (v *validation) field(body map[string]interface{}, option ValidationOption, path string, policy UnknownKeys) bool {
+	value, exists := body[option.Key]
+	policy = option.UnknownKeys.inherit(policy)
+	field := FieldContext{Key: option.Key, Exists: exists, Parent: body, Root: v.root}
 
-		// Run all validators for the field (if it exists)
+	// Only cross-field validators such as RequiredIf apply to an optional field that is not present or a nullable null field
+	if (option.IsOptional && !exists) || (option.Nullable && exists && value == nil) {
 		for _, validator := range option.Validators {
-			if err := validator.Func(value); err != nil {
-				if validator.Message == "" {
-					return err
-				}
-				return fmt.Errorf("%s", validator.Message)
+			if validator.CrossFunc == nil {
+				continue
+			}
+			if err := validator.CrossFunc(nil, field); err != nil {
+				return v.failValidator(path, nil, validator, err)
 			}
 		}
+		return true
+	}
 
-		// Check if the field is required but missing
-		if !option.IsOptional && !exists {
-			return fmt.Errorf("'%s' is required", option.Key)
+	// Check if the field is required but missing *before* running validators
+	if !option.IsOptional && !exists {
+		e := newError("required", CodeRequired, "required", nil, Params{"field": option.Key})
+		e.Field = path
+		return v.fail(e)
+	}
+
+	// A null value is only accepted by nullable fields
+	if value == nil {
+		e := newError("nullable", CodeNull, "null", nil, Params{"field": option.Key})
+		e.Field = path
+		return v.fail(e)
+	}
+
+	// Apply transformations
+	for _, transformer := range option.Transformers {
+		value = transformer(value)
+	}
+	for _, transformer := range option.FallibleTransformers {
+		transformed, err := transformer(value)
+		if err != nil {
+			body[option.Key] = value
+			return v.failValidator(path, value, Validator{Rule: ruleName(transformer)}, err)
 		}
+		value = transformed
+	}
+	body[option.Key] = value // Update the body with the transformed value
+
+	// Expensive validators are deferred to the pool in concurrent mode
+	// Context validators such as EachWithOptionsContext apply the field's unknown keys policy to their elements
+	ctx := v.validatorContext(policy, option.Validators)
+	validators, job := v.deferExpensive(path, option.Validators)
+	if job != nil {
+		defer v.finishJob(ctx, job, value)
+	}
 
-		// Handle nested validation
-		if option.Nested != nil {
-			nestedBody, ok := value.(map[string]interface{})
-			if !ok {
-				return fmt.Errorf("'%s' must be an object", option.Key)
+	// Run all validators for the field, stopping at the first failure
+	for _, validator := range validators {
+		if err := validator.run(ctx, value, field); err != nil {
+			if job != nil {
+				// Expensive validators only run once the others passed
+				job.tasks = nil
 			}
-			if err := Validate(nestedBody, option.Nested); err != nil {
-				return err
+			return v.failValidator(path, value, validator, err)
+		}
+	}
+
+	// Handle nested validation, following the reference of the field if any
+	nested := option.Nested
+	if option.Ref != "" {
+		options, r, err := v.refs.follow(option.Ref)
+		if err != nil {
+			if isInternal(err) {
+				return v.abort(err)
 			}
+			e := err.(*ValidationError)
+			e.Field = path
+			return v.fail(e)
 		}
+		parent := v.refs
+		v.refs = r
+		defer func() { v.refs = parent }()
+		nested = options
+	}
+	if nested != nil {
+		nestedBody, ok := value.(map[string]interface{})
+		if !ok {
+			e := invalidType("object", "object", value, Params{"field": option.Key})
+			e.Field = path
+			return v.fail(e)
+		}
+		return v.object(nestedBody, nested, path, policy)
 	}
 
-	return nil
+	return true
 }
 
-// Helper function to create a validator
+// Helper function to create a validator.
+// The message may reference {field}, {path}, {value}, {len}, {error} and the parameters of built-in rules such as {min},
+// use "{{" and "}}" for literal braces.
 func CreateValidator(fn ValidatorFunc, message string) Validator {
 	return Validator{
 		Func:    fn,
@@ -88,27 +423,18 @@ func CreateValidator(fn ValidatorFunc, message string) Validator {
 	}
 }
 
-// StructToMap converts a struct to a map[string]interface{} for validation
-func StructToMap(obj interface{}) map[string]interface{} {
-	result := make(map[string]interface{})
-	v := reflect.ValueOf(obj)
-	t := reflect.TypeOf(obj)
+// Helper function to create a context validator
+func CreateContextValidator(fn ContextValidatorFunc, message string) Validator {
+	return Validator{
+		ContextFunc: fn,
+		Message:     message,
+	}
+}
 
-	for i := range v.NumField() {
-		field := t.Field(i)
-		// Use the json tag if present, otherwise fall back to field name
-		jsonTag := field.Tag.Get("json")
-		key := field.Name
-		if jsonTag != "" {
-			// Split on "," to handle options like "omitempty"
-			if parts := strings.Split(jsonTag, ","); len(parts) > 0 {
-				key = parts[0]
-			}
-		}
-		// Only process exported fields
-		if field.PkgPath == "" { // PkgPath is empty for exported fields
-			result[key] = v.Field(i).Interface()
-		}
+// Helper function to create a cross-field validator
+func CreateCrossFieldValidator(fn CrossFieldFunc, message string) Validator {
+	return Validator{
+		CrossFunc: fn,
+		Message:   message,
 	}
-	return result
 }
@@ -1,8 +1,10 @@
 package validator
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -24,7 +26,11 @@ func TestRegex(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := regex(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -103,7 +109,11 @@ func TestValidate(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := Validate(test.input, options)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -171,7 +181,11 @@ func TestNestedValidation(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := Validate(test.input, options)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -201,7 +215,11 @@ func TestCustomValidator(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := customValidator.Func(test.input)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
@@ -250,7 +268,254 @@ func TestIntegration(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			err := Validate(test.input, options)
-			require.Equal(t, test.error, err)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
 		})
 	}
 }
+
+func TestValidateAll(t *testing.T) {
+	options := []ValidationOption{
+		{
+			Key: "username",
+			Validators: []Validator{
+				CreateValidator(IsNotEmpty, "Username is required"),
+				CreateValidator(IsAlphanumeric, "Username must be alphanumeric"),
+			},
+		},
+		{
+			Key: "email",
+			Validators: []Validator{
+				CreateValidator(IsEmail, "Invalid email address"),
+			},
+		},
+		{
+			Key: "address",
+			Nested: []ValidationOption{
+				{
+					Key: "city",
+					Validators: []Validator{
+						CreateValidator(IsNotEmpty, "City is required"),
+					},
+				},
+			},
+		},
+		{
+			Key:        "items",
+			IsOptional: true,
+			Validators: []Validator{
+				CreateValidator(EachWithOptions([]ValidationOption{
+					{
+						Key: "sku",
+						Validators: []Validator{
+							CreateValidator(IsNotEmpty, "SKU is required"),
+						},
+					},
+				}), ""),
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		expected []string
+	}{
+		{
+			"valid input",
+			map[string]interface{}{
+				"username": "user123",
+				"email":    "user@example.com",
+				"address":  map[string]interface{}{"city": "Algiers"},
+			},
+			nil,
+		},
+		{
+			"every failure is reported",
+			map[string]interface{}{
+				"username": "",
+				"address":  map[string]interface{}{"city": ""},
+				"items": []interface{}{
+					map[string]interface{}{"sku": ""},
+					map[string]interface{}{"sku": "A1"},
+					map[string]interface{}{},
+				},
+			},
+			[]string{"Username is required", "email is required", "City is required", "SKU is required", "sku is required"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateAll(test.input, options)
+			if test.expected == nil {
+				require.NoError(t, err)
+				return
+			}
+			var errs ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			messages := make([]string, len(errs))
+			for i, e := range errs {
+				messages[i] = e.Error()
+			}
+			require.Equal(t, test.expected, messages)
+		})
+	}
+
+	t.Run("validate keeps the first error", func(t *testing.T) {
+		err := Validate(map[string]interface{}{"username": ""}, options)
+		require.EqualError(t, err, "Username is required")
+	})
+}
+
+func TestNullable(t *testing.T) {
+	options := Object(
+		Field("nickname").Optional().Nullable().Trim().MinLength(3),
+		Field("bio").Optional().String(),
+		Field("address").Nullable().Object(
+			Field("city").String(),
+		),
+		Field("vat").Nullable().RequiredIf("country", "FR"),
+	)
+
+	tests := []struct {
+		name  string
+		body  map[string]interface{}
+		error error
+	}{
+		{"absent optional fields are skipped", map[string]interface{}{"address": nil, "vat": "x"}, nil},
+		{"null skips validators and nested options of nullable fields", map[string]interface{}{"nickname": nil, "address": nil, "vat": nil}, nil},
+		{"values of nullable fields are validated", map[string]interface{}{"nickname": " ab ", "address": nil, "vat": nil}, errors.New("value must be at least 3 characters long")},
+		{"null is rejected by fields that are not nullable", map[string]interface{}{"bio": nil, "address": nil, "vat": nil}, errors.New("bio must not be null")},
+		{"cross-field validators run on null", map[string]interface{}{"address": nil, "vat": nil, "country": "FR"}, errors.New("vat is required when country is one of [FR]")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Validate(test.body, options)
+			if test.error == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error.Error())
+			}
+		})
+	}
+
+	t.Run("nullable fields are still required", func(t *testing.T) {
+		err := Validate(map[string]interface{}{}, Object(Field("name").Nullable()))
+		require.EqualError(t, err, "name is required")
+	})
+
+	t.Run("null is reported with the null code", func(t *testing.T) {
+		err := Validate(map[string]interface{}{"bio": nil, "address": nil, "vat": nil}, options)
+		var e *ValidationError
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, CodeNull, e.Code)
+		require.Equal(t, "bio", e.Field)
+	})
+}
+
+func TestValidateContext(t *testing.T) {
+	taken := map[string]bool{"admin": true}
+	unique := func(ctx context.Context, value interface{}) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		username, _ := value.(string)
+		if username == "db-down" {
+			return Internal(errors.New("connection refused"))
+		}
+		if taken[username] {
+			return errors.New("username is already taken")
+		}
+		return nil
+	}
+
+	options := []ValidationOption{
+		{
+			Key: "username",
+			Validators: []Validator{
+				CreateValidator(IsNotEmpty, "Username is required"),
+				CreateContextValidator(unique, ""),
+			},
+		},
+		{
+			Key:        "members",
+			IsOptional: true,
+			Validators: []Validator{
+				CreateContextValidator(EachWithOptionsContext([]ValidationOption{
+					{
+						Key:        "username",
+						Validators: []Validator{CreateContextValidator(unique, "")},
+					},
+				}), ""),
+			},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		input map[string]interface{}
+		error string
+	}{
+		{"valid input", map[string]interface{}{"username": "user123"}, ""},
+		{"validation failure", map[string]interface{}{"username": "admin"}, "username is already taken"},
+		{"nested validation failure", map[string]interface{}{
+			"username": "user123",
+			"members":  []interface{}{map[string]interface{}{"username": "admin"}},
+		}, "username is already taken"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateContext(context.Background(), test.input, options)
+			if test.error == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.error)
+			}
+		})
+	}
+
+	t.Run("internal errors abort validation", func(t *testing.T) {
+		for _, body := range []map[string]interface{}{
+			{"username": "db-down"},
+			{"username": "user123", "members": []interface{}{map[string]interface{}{"username": "db-down"}}},
+		} {
+			err := ValidateContext(context.Background(), body, options, CollectAll())
+			var internal *InternalError
+			require.ErrorAs(t, err, &internal)
+			require.EqualError(t, err, "connection refused")
+			var ve *ValidationError
+			require.False(t, errors.As(err, &ve))
+		}
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := ValidateContext(ctx, map[string]interface{}{"username": "user123"}, options)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("deadline exceeded in a validator", func(t *testing.T) {
+		slow := []ValidationOption{
+			{
+				Key: "username",
+				Validators: []Validator{
+					CreateContextValidator(func(ctx context.Context, value interface{}) error {
+						<-ctx.Done()
+						return ctx.Err()
+					}, "Username is invalid"),
+				},
+			},
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err := ValidateContext(ctx, map[string]interface{}{"username": "user123"}, slow)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -1,7 +1,6 @@
 package validator
 
 import (
-	"errors"
 	"fmt"
 	"regexp"
 )
@@ -13,13 +12,16 @@ func Regex(pattern string) ValidatorFunc {
 		panic(fmt.Sprintf("Invalid regex pattern: %s", err))
 	}
 	return func(value interface{}) error {
+		if value == nil {
+			return invalidType("Regex", "nil", value, nil)
+		}
 		str, ok := value.(string)
 		if !ok {
-			return errors.New("value must be a string")
+			return invalidType("Regex", "string", value, nil)
 		}
 
 		if !re.MatchString(str) {
-			return errors.New("value does not match the required pattern")
+			return invalid("Regex", "pattern", value, nil)
 		}
 
 		return nil